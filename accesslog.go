@@ -0,0 +1,243 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SetSlowRequests sets the duration from which AccessLog logs requests at
+// WARNING Level with the field slow, the timings added with AddTiming and
+// the given request headers, with the values of DefaultRedactHeaders
+// redacted. A zero threshold disables it.
+func (l *Logger) SetSlowRequests(threshold time.Duration, headers ...string) {
+	l.update(func(c *config) {
+		c.http.slowRequest = threshold
+		c.http.slowHeaders = headers
+	})
+}
+
+// SetAccessLogSampling sets AccessLog to log only every n-th request with
+// a 2xx status, adding the field sample_rate. Other and slow requests are
+// always logged. An n below 2 disables sampling.
+func (l *Logger) SetAccessLogSampling(n int) {
+	l.update(func(c *config) {
+		if n < 2 {
+			c.http.sampled = nil
+			return
+		}
+		c.http.sampled = &accessSampler{every: uint64(n)}
+	})
+}
+
+// SuppressPaths sets the paths of requests that AccessLog does not log,
+// such as /healthz and /metrics. Paths ending in * match by prefix.
+func (l *Logger) SuppressPaths(paths ...string) {
+	l.update(func(c *config) {
+		c.http.suppressed = append([]string(nil), paths...)
+	})
+}
+
+// suppressedPath reports whether requests for the path are not logged.
+func (c *config) suppressedPath(path string) bool {
+	for _, p := range c.http.suppressed {
+		if strings.HasSuffix(p, "*") && strings.HasPrefix(path, p[:len(p)-1]) || p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRoutePattern sets the function returning the route pattern of a
+// request, like /users/{id}, added by AccessLog and Recover as the field
+// route after the request was served. Routers that match in middleware,
+// like Chi, set the pattern on the request context:
+//
+//	logger.SetRoutePattern(func(r *http.Request) string {
+//		return chi.RouteContext(r.Context()).RoutePattern()
+//	})
+func (l *Logger) SetRoutePattern(fn func(r *http.Request) string) {
+	l.update(func(c *config) {
+		c.http.route = fn
+	})
+}
+
+// routePattern adds the route pattern of the request to the fields.
+func (c *config) routePattern(r *http.Request, fields Fields) {
+	if c.http.route == nil {
+		return
+	}
+	if pattern := c.http.route(r); pattern != "" {
+		fields["route"] = pattern
+	}
+}
+
+type timingsKey struct{}
+
+// timings are the durations of upstream calls of a request.
+type timings struct {
+	mutex  sync.Mutex
+	values Fields
+}
+
+// AddTiming adds the duration of an upstream call, such as a database
+// query, to the request of the context, for slow requests logged by
+// AccessLog. Durations with the same name are summed.
+func AddTiming(ctx context.Context, name string, d time.Duration) {
+	t, ok := ctx.Value(timingsKey{}).(*timings)
+	if !ok {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	sum, _ := t.values[name].(Duration)
+	t.values[name] = sum + Duration(d)
+}
+
+// AccessLog returns a handler logging each request at INFO Level with the
+// Fields of its context and the fields remote_addr, user, method, path,
+// query, proto, status, bytes, duration, referer and user_agent. It has
+// the signature of net/http middleware used by routers like Chi.
+func (l *Logger) AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		c := l.config()
+		if c.suppressedPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var t *timings
+		if c.http.slowRequest > 0 {
+			t = &timings{values: Fields{}}
+			r = r.WithContext(context.WithValue(r.Context(), timingsKey{}, t))
+		}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		duration := time.Since(start)
+		level := INFO
+		if c.http.slowRequest > 0 && duration >= c.http.slowRequest {
+			level = WARNING
+		}
+		if !l.enabled(level) {
+			return
+		}
+		sampled := c.http.sampled != nil && level == INFO && rec.status >= 200 && rec.status < 300
+		if sampled && atomic.AddUint64(&c.http.sampled.count, 1)%c.http.sampled.every != 1 {
+			return
+		}
+
+		fields := Fields{}
+		for k, v := range FieldsFromContext(r.Context()) {
+			fields[k] = v
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		fields["remote_addr"] = host
+		if user, _, ok := r.BasicAuth(); ok {
+			fields["user"] = user
+		}
+		fields["method"] = r.Method
+		fields["path"] = r.URL.Path
+		c.routePattern(r, fields)
+		fields["query"] = r.URL.RawQuery
+		fields["proto"] = r.Proto
+		fields["status"] = rec.status
+		fields["bytes"] = rec.bytes
+		fields["duration"] = duration
+		fields["referer"] = r.Referer()
+		fields["user_agent"] = r.UserAgent()
+		if sampled {
+			fields["sample_rate"] = c.http.sampled.every
+		}
+		if level == WARNING {
+			fields["slow"] = true
+			t.mutex.Lock()
+			if len(t.values) > 0 {
+				fields["timings"] = t.values
+			}
+			t.mutex.Unlock()
+			if len(c.http.slowHeaders) > 0 {
+				fields["request_headers"] = headerSubset(r.Header, c.http.slowHeaders)
+			}
+		}
+		l.output(Entry{
+			Time:    start,
+			Level:   level,
+			Name:    c.name,
+			Message: r.Method + " " + r.URL.RequestURI(),
+			Fields:  fields,
+			Scope:   c.scope,
+		})
+	})
+}
+
+// headerSubset returns the values of the headers, with the values of
+// DefaultRedactHeaders redacted.
+func headerSubset(h http.Header, headers []string) Fields {
+	fields := Fields{}
+	for _, k := range headers {
+		k = http.CanonicalHeaderKey(k)
+		v, ok := h[k]
+		if !ok {
+			continue
+		}
+		fields[k] = strings.Join(v, ", ")
+		for _, redact := range DefaultRedactHeaders {
+			if http.CanonicalHeaderKey(redact) == k {
+				fields[k] = redacted
+			}
+		}
+	}
+	return fields
+}
+
+// statusRecorder records the status and size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += n
+	return n, err
+}
+
+// Flush flushes the response if it supports it, e.g. for streaming.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack takes over the connection if the response supports it, e.g. for
+// WebSockets.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("slogx: response does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+// Unwrap returns the wrapped response for http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
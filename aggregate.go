@@ -0,0 +1,152 @@
+package slogx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AggregateHook is a Hook that groups entries by their signature, the
+// Level, name and message with numbers ignored, and fires a single digest
+// per group and window to its Hook instead of one entry each. It is meant
+// for alerting Hooks such as webhooks or email.
+type AggregateHook struct {
+	Hook   Hook
+	Window time.Duration
+	Mutex  sync.Mutex
+	groups map[string]*aggregate
+	done   chan struct{}
+	once   sync.Once
+}
+
+type aggregate struct {
+	entry Entry
+	count int
+	last  time.Time
+}
+
+// NewAggregateHook returns a new AggregateHook firing the digests of every
+// window to the Hook. The window must be positive.
+func NewAggregateHook(hook Hook, window time.Duration) (*AggregateHook, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("slogx: invalid window '%s'", window)
+	}
+	h := &AggregateHook{
+		Hook:   hook,
+		Window: window,
+		groups: make(map[string]*aggregate),
+		done:   make(chan struct{}),
+	}
+	go h.run(window)
+	return h, nil
+}
+
+func (h *AggregateHook) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := h.Flush(); err != nil {
+				diagnose(ERROR, Fields{"error": err, "hook": "aggregate"}, "flush failed")
+			}
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// Fire adds the Entry to the group of its signature.
+func (h *AggregateHook) Fire(e Entry) error {
+	key := signature(e)
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	if g, ok := h.groups[key]; ok {
+		g.count++
+		g.last = e.Time
+		return nil
+	}
+	h.groups[key] = &aggregate{entry: e, count: 1, last: e.Time}
+	return nil
+}
+
+// Flush fires a digest of every group to the Hook, in the order of their
+// first entries. A group of a single entry fires the entry itself. A digest
+// is its first entry with the message suffixed by the count, like
+// "payment failed ×37 in 5m0s", and the fields count, first and last.
+func (h *AggregateHook) Flush() error {
+	h.Mutex.Lock()
+	groups := make([]*aggregate, 0, len(h.groups))
+	for _, g := range h.groups {
+		groups = append(groups, g)
+	}
+	h.groups = make(map[string]*aggregate)
+	h.Mutex.Unlock()
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].entry.Time.Before(groups[j].entry.Time)
+	})
+
+	var errs []string
+	for _, g := range groups {
+		if err := h.Hook.Fire(g.digest(h.Window)); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("slogx: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (g *aggregate) digest(window time.Duration) Entry {
+	e := g.entry
+	if g.count == 1 {
+		return e
+	}
+	fields := make(Fields, len(e.Fields)+3)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields["count"] = g.count
+	fields["first"] = e.Time.Round(0)
+	fields["last"] = g.last.Round(0)
+	e.Fields = fields
+	e.Message = fmt.Sprintf("%s ×%d in %s", e.Message, g.count, window)
+	e.Time = g.last
+	return e
+}
+
+// Close fires the current digests and stops firing them periodically.
+// Closing it again does nothing.
+func (h *AggregateHook) Close() error {
+	var err error
+	h.once.Do(func() {
+		close(h.done)
+		err = h.Flush()
+	})
+	return err
+}
+
+// signature returns the key of the group of the Entry.
+func signature(e Entry) string {
+	var b strings.Builder
+	b.WriteString(e.Level.String())
+	b.WriteString("\x00")
+	b.WriteString(e.Name)
+	b.WriteString("\x00")
+	digits := false
+	for _, r := range e.Message {
+		if r >= '0' && r <= '9' {
+			if !digits {
+				b.WriteByte('#')
+			}
+			digits = true
+			continue
+		}
+		digits = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,209 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const azureDefaultEndpoint = "https://dc.services.visualstudio.com/"
+
+var levelToAzureSeverity = map[Level]int{
+	DEBUG:   0,
+	INFO:    1,
+	WARNING: 2,
+	ERROR:   3,
+	FATAL:   4,
+}
+
+type azureEnvelope struct {
+	Name string            `json:"name"`
+	Time string            `json:"time"`
+	IKey string            `json:"iKey"`
+	Tags map[string]string `json:"tags"`
+	Data azureData         `json:"data"`
+}
+
+type azureData struct {
+	BaseType string                 `json:"baseType"`
+	BaseData map[string]interface{} `json:"baseData"`
+}
+
+// AzureHook is a Hook that sends entries to Azure Application Insights.
+// ERROR and FATAL entries are sent as exceptions, all others as traces.
+type AzureHook struct {
+	InstrumentationKey string
+	Endpoint           string
+	BatchSize          int
+	Client             *http.Client
+	DeadLetter         string
+	Mutex              sync.Mutex
+	batch              []Entry
+	done               chan struct{}
+	full               chan struct{}
+	once               sync.Once
+}
+
+// NewAzureHook returns a new AzureHook for a connection string or an
+// instrumentation key. Entries are sent when BatchSize is reached and
+// every interval, which must be positive.
+func NewAzureHook(connectionString string, interval time.Duration) (*AzureHook, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("slogx: invalid interval '%s'", interval)
+	}
+	h := &AzureHook{
+		Endpoint:  azureDefaultEndpoint,
+		BatchSize: 100,
+		Client:    http.DefaultClient,
+		done:      make(chan struct{}),
+		full:      make(chan struct{}, 1),
+	}
+	if !strings.Contains(connectionString, "=") {
+		h.InstrumentationKey = connectionString
+	}
+	for _, part := range strings.Split(connectionString, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "instrumentationkey":
+			h.InstrumentationKey = kv[1]
+		case "ingestionendpoint":
+			h.Endpoint = kv[1]
+		}
+	}
+	if h.InstrumentationKey == "" {
+		return nil, fmt.Errorf("slogx: invalid connection string '%s'", connectionString)
+	}
+	go h.run(interval)
+	return h, nil
+}
+
+func (h *AzureHook) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.full:
+		case <-h.done:
+			return
+		}
+		if err := h.Flush(); err != nil {
+			diagnose(ERROR, Fields{"error": err, "hook": "azure"}, "flush failed")
+		}
+	}
+}
+
+// Fire adds the Entry to the current batch. A full batch is sent in the
+// background.
+func (h *AzureHook) Fire(e Entry) error {
+	h.Mutex.Lock()
+	h.batch = append(h.batch, e)
+	full := len(h.batch) >= h.BatchSize
+	h.Mutex.Unlock()
+	if full {
+		select {
+		case h.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (h *AzureHook) envelope(e Entry) azureEnvelope {
+	env := azureEnvelope{
+		Time: e.Time.UTC().Format(time.RFC3339Nano),
+		IKey: h.InstrumentationKey,
+		Tags: map[string]string{"ai.cloud.role": e.Name},
+	}
+	properties := map[string]string{
+		"logger": e.Name,
+		"level":  e.Level.String(),
+		"file":   e.File + ":" + strconv.Itoa(e.Line),
+	}
+	for k, v := range e.Fields {
+		properties[k] = fmt.Sprint(v)
+	}
+	baseData := map[string]interface{}{
+		"ver":           2,
+		"severityLevel": levelToAzureSeverity[e.Level],
+		"properties":    properties,
+	}
+	if e.Level == ERROR || e.Level == FATAL {
+		env.Name = "Microsoft.ApplicationInsights.Exception"
+		baseData["exceptions"] = []map[string]interface{}{{
+			"typeName":     e.Level.String(),
+			"message":      e.Message,
+			"hasFullStack": false,
+		}}
+		env.Data = azureData{BaseType: "ExceptionData", BaseData: baseData}
+	} else {
+		env.Name = "Microsoft.ApplicationInsights.Message"
+		baseData["message"] = e.Message
+		env.Data = azureData{BaseType: "MessageData", BaseData: baseData}
+	}
+	return env
+}
+
+// Flush sends the current batch. If sending fails and DeadLetter is set,
+// the batch is appended to the dead-letter file.
+func (h *AzureHook) Flush() error {
+	h.Mutex.Lock()
+	batch := h.batch
+	h.batch = nil
+	h.Mutex.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	err := h.send(batch)
+	if err != nil && h.DeadLetter != "" {
+		if err := WriteDeadLetter(h.DeadLetter, batch); err != nil {
+			return err
+		}
+		diagnose(WARNING, Fields{"error": err, "hook": "azure", "entries": len(batch), "path": h.DeadLetter},
+			"batch written to dead-letter file")
+	}
+	return err
+}
+
+func (h *AzureHook) send(batch []Entry) error {
+	envelopes := make([]azureEnvelope, len(batch))
+	for i, e := range batch {
+		envelopes[i] = h.envelope(e)
+	}
+	body, err := json.Marshal(envelopes)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(h.Endpoint, "/") + "/v2/track"
+	resp, err := h.Client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("slogx: application insights returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// Close sends the current batch and stops sending entries periodically.
+// Closing it again does nothing.
+func (h *AzureHook) Close() error {
+	var err error
+	h.once.Do(func() {
+		close(h.done)
+		err = h.Flush()
+	})
+	return err
+}
@@ -0,0 +1,73 @@
+package slogx
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+)
+
+// Banner logs a "started" message at INFO Level with the app name and
+// version, the build info, the Go version, platform, process ID and host
+// name, and the Fields given, for example a summary of the configuration.
+// If the Logger writes text to a terminal, a multi-line banner is logged
+// instead.
+func (l *Logger) Banner(app string, version string, fields ...Fields) {
+	if !l.enabled(INFO) {
+		return
+	}
+	f := Fields{}
+	for _, fs := range fields {
+		for k, v := range fs {
+			f[k] = v
+		}
+	}
+	f["app"] = app
+	f["version"] = version
+	if buildCommit != "" {
+		f["commit"] = buildCommit
+	}
+	if buildTime != "" {
+		f["build_time"] = buildTime
+	}
+	f["go_version"] = runtime.Version()
+	f["platform"] = runtime.GOOS + "/" + runtime.GOARCH
+	f["pid"] = os.Getpid()
+	if host, err := os.Hostname(); err == nil {
+		f["host"] = host
+	}
+
+	c := l.config()
+	if out, ok := c.output.(*os.File); ok && c.encoder == nil && terminalWidth(out) > 0 {
+		l.log(INFO, nil, banner(f))
+		return
+	}
+	l.log(INFO, f, "started")
+}
+
+// banner returns the Fields as a framed block headed by app and version.
+func banner(f Fields) string {
+	title := fmt.Sprintf("%v %v", f["app"], f["version"])
+	lines := []string{title, ""}
+	width := utf8.RuneCountInString(title)
+	for _, k := range f.sortedKeys() {
+		if k == "app" || k == "version" {
+			continue
+		}
+		line := fmt.Sprintf("%-12s %v", k, f[k])
+		if n := utf8.RuneCountInString(line); n > width {
+			width = n
+		}
+		lines = append(lines, line)
+	}
+
+	var b strings.Builder
+	b.WriteString("started\n")
+	b.WriteString("+" + strings.Repeat("-", width+2) + "+\n")
+	for _, line := range lines {
+		b.WriteString("| " + line + strings.Repeat(" ", width-utf8.RuneCountInString(line)) + " |\n")
+	}
+	b.WriteString("+" + strings.Repeat("-", width+2) + "+")
+	return b.String()
+}
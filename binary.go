@@ -0,0 +1,315 @@
+package slogx
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// binaryEncoder is implemented by encoders whose records are written
+// without a trailing newline.
+type binaryEncoder interface {
+	binary()
+}
+
+// MsgpackEncoder encodes entries as MessagePack maps with the same keys as
+// a JSONEncoder. The time is encoded as a MessagePack timestamp.
+type MsgpackEncoder struct{}
+
+// NewMsgpackEncoder returns a new MsgpackEncoder.
+func NewMsgpackEncoder() *MsgpackEncoder {
+	return &MsgpackEncoder{}
+}
+
+func newMsgpackEncoder(options map[string]string) (Encoder, error) {
+	return NewMsgpackEncoder(), nil
+}
+
+func (enc *MsgpackEncoder) binary() {}
+
+// Encode returns the Entry as a MessagePack map.
+func (enc *MsgpackEncoder) Encode(e Entry) ([]byte, error) {
+	keys, values := entryPairs(e)
+	b := appendMsgpackHead(nil, 0x80, 0xde, 0xdf, len(keys))
+	for i, k := range keys {
+		b = appendMsgpack(b, k)
+		b = appendMsgpack(b, values[i])
+	}
+	return b, nil
+}
+
+// CBOREncoder encodes entries as CBOR maps with the same keys as a
+// JSONEncoder. The time is encoded as an RFC 3339 date/time string.
+type CBOREncoder struct{}
+
+// NewCBOREncoder returns a new CBOREncoder.
+func NewCBOREncoder() *CBOREncoder {
+	return &CBOREncoder{}
+}
+
+func newCBOREncoder(options map[string]string) (Encoder, error) {
+	return NewCBOREncoder(), nil
+}
+
+func (enc *CBOREncoder) binary() {}
+
+// Encode returns the Entry as a CBOR map.
+func (enc *CBOREncoder) Encode(e Entry) ([]byte, error) {
+	keys, values := entryPairs(e)
+	b := appendCBORHead(nil, 5, uint64(len(keys)))
+	for i, k := range keys {
+		b = appendCBOR(b, k)
+		b = appendCBOR(b, values[i])
+	}
+	return b, nil
+}
+
+// entryPairs returns the keys and values of the Entry in the order and
+// with the names used by a JSONEncoder.
+func entryPairs(e Entry) ([]string, []interface{}) {
+	keys := []string{"time", "level", "name", "file", "line", "message"}
+	values := []interface{}{e.Time, e.Level.String(), e.Name, e.File, e.Line, e.Message}
+	if e.Scope != "" {
+		keys = append(keys, "scope")
+		values = append(values, e.Scope)
+	}
+	for _, k := range e.Fields.sortedKeys() {
+		key := k
+		if jsonKeys[k] {
+			key = "fields." + k
+		}
+		keys = append(keys, key)
+		values = append(values, e.Fields[k])
+	}
+	return keys, values
+}
+
+func appendMsgpackHead(b []byte, fix byte, op16 byte, op32 byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return appendUint16(append(b, op16), uint16(n))
+	default:
+		return appendUint32(append(b, op32), uint32(n))
+	}
+}
+
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return appendMsgpackUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return appendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return appendUint32(append(b, 0xd2), uint32(n))
+	default:
+		return appendUint64(append(b, 0xd3), uint64(n))
+	}
+}
+
+func appendMsgpackUint(b []byte, n uint64) []byte {
+	switch {
+	case n < 128:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return appendUint16(append(b, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		return appendUint32(append(b, 0xce), uint32(n))
+	default:
+		return appendUint64(append(b, 0xcf), n)
+	}
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = appendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = appendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendMsgpack(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if v {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case int:
+		return appendMsgpackInt(b, int64(v))
+	case int8:
+		return appendMsgpackInt(b, int64(v))
+	case int16:
+		return appendMsgpackInt(b, int64(v))
+	case int32:
+		return appendMsgpackInt(b, int64(v))
+	case int64:
+		return appendMsgpackInt(b, v)
+	case uint:
+		return appendMsgpackUint(b, uint64(v))
+	case uint8:
+		return appendMsgpackUint(b, uint64(v))
+	case uint16:
+		return appendMsgpackUint(b, uint64(v))
+	case uint32:
+		return appendMsgpackUint(b, uint64(v))
+	case uint64:
+		return appendMsgpackUint(b, v)
+	case float32:
+		return appendUint32(append(b, 0xca), math.Float32bits(v))
+	case float64:
+		return appendUint64(append(b, 0xcb), math.Float64bits(v))
+	case string:
+		return appendMsgpackString(b, v)
+	case []byte:
+		switch n := len(v); {
+		case n <= math.MaxUint8:
+			b = append(b, 0xc4, byte(n))
+		case n <= math.MaxUint16:
+			b = appendUint16(append(b, 0xc5), uint16(n))
+		default:
+			b = appendUint32(append(b, 0xc6), uint32(n))
+		}
+		return append(b, v...)
+	case time.Time:
+		b = append(b, 0xc7, 12, 0xff)
+		b = appendUint32(b, uint32(v.Nanosecond()))
+		return appendUint64(b, uint64(v.Unix()))
+	case time.Duration:
+		return appendMsgpack(b, int64(v))
+	case rawValuer:
+		return appendMsgpack(b, v.raw())
+	case error:
+		return appendMsgpackString(b, v.Error())
+	case Fields:
+		return appendMsgpack(b, map[string]interface{}(v))
+	case map[string]interface{}:
+		b = appendMsgpackHead(b, 0x80, 0xde, 0xdf, len(v))
+		for _, k := range Fields(v).sortedKeys() {
+			b = appendMsgpackString(b, k)
+			b = appendMsgpack(b, v[k])
+		}
+		return b
+	case []interface{}:
+		b = appendMsgpackHead(b, 0x90, 0xdc, 0xdd, len(v))
+		for _, item := range v {
+			b = appendMsgpack(b, item)
+		}
+		return b
+	}
+	return appendMsgpackString(b, fmt.Sprint(v))
+}
+
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return appendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return appendUint32(append(b, major|26), uint32(n))
+	default:
+		return appendUint64(append(b, major|27), n)
+	}
+}
+
+func appendCBORInt(b []byte, n int64) []byte {
+	if n < 0 {
+		return appendCBORHead(b, 1, uint64(-1-n))
+	}
+	return appendCBORHead(b, 0, uint64(n))
+}
+
+func appendCBOR(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xf6)
+	case bool:
+		if v {
+			return append(b, 0xf5)
+		}
+		return append(b, 0xf4)
+	case int:
+		return appendCBORInt(b, int64(v))
+	case int8:
+		return appendCBORInt(b, int64(v))
+	case int16:
+		return appendCBORInt(b, int64(v))
+	case int32:
+		return appendCBORInt(b, int64(v))
+	case int64:
+		return appendCBORInt(b, v)
+	case uint:
+		return appendCBORHead(b, 0, uint64(v))
+	case uint8:
+		return appendCBORHead(b, 0, uint64(v))
+	case uint16:
+		return appendCBORHead(b, 0, uint64(v))
+	case uint32:
+		return appendCBORHead(b, 0, uint64(v))
+	case uint64:
+		return appendCBORHead(b, 0, v)
+	case float32:
+		return appendUint32(append(b, 0xfa), math.Float32bits(v))
+	case float64:
+		return appendUint64(append(b, 0xfb), math.Float64bits(v))
+	case string:
+		return append(appendCBORHead(b, 3, uint64(len(v))), v...)
+	case []byte:
+		return append(appendCBORHead(b, 2, uint64(len(v))), v...)
+	case time.Time:
+		return appendCBOR(append(b, 0xc0), v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return appendCBOR(b, int64(v))
+	case rawValuer:
+		return appendCBOR(b, v.raw())
+	case error:
+		return appendCBOR(b, v.Error())
+	case Fields:
+		return appendCBOR(b, map[string]interface{}(v))
+	case map[string]interface{}:
+		b = appendCBORHead(b, 5, uint64(len(v)))
+		for _, k := range Fields(v).sortedKeys() {
+			b = appendCBOR(b, k)
+			b = appendCBOR(b, v[k])
+		}
+		return b
+	case []interface{}:
+		b = appendCBORHead(b, 4, uint64(len(v)))
+		for _, item := range v {
+			b = appendCBOR(b, item)
+		}
+		return b
+	}
+	return appendCBOR(b, fmt.Sprint(v))
+}
+
+func appendUint16(b []byte, n uint16) []byte {
+	return append(b, byte(n>>8), byte(n))
+}
+
+func appendUint32(b []byte, n uint32) []byte {
+	return append(b, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+func appendUint64(b []byte, n uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(n>>32)), uint32(n))
+}
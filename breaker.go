@@ -0,0 +1,122 @@
+package slogx
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// breaker opens after a number of consecutive failures and lets a single
+// probe through once the cooldown has passed.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	mutex     sync.Mutex
+	failures  int
+	openUntil time.Time
+	dropped   uint64
+}
+
+// allow reports whether a call may be made.
+func (b *breaker) allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) {
+		b.dropped++
+		return false
+	}
+	b.openUntil = time.Now().Add(b.cooldown)
+	return true
+}
+
+// done records the result of a call.
+func (b *breaker) done(err error) {
+	b.mutex.Lock()
+	opened := err != nil && b.failures+1 == b.threshold
+	closed := err == nil && b.failures >= b.threshold
+	dropped := b.dropped
+	if err == nil {
+		b.failures = 0
+	} else {
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+	}
+	b.mutex.Unlock()
+	if opened {
+		diagnose(WARNING, Fields{"error": err}, "circuit breaker open")
+	} else if closed {
+		diagnose(INFO, Fields{"dropped": dropped}, "circuit breaker closed")
+	}
+}
+
+// Dropped returns the number of calls rejected while open.
+func (b *breaker) Dropped() uint64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.dropped
+}
+
+// CircuitBreaker is an io.Writer that stops writing to its Output after
+// Threshold consecutive failures. While open, writes go to the Fallback if
+// set and are dropped otherwise. After each Cooldown a single write is
+// let through to probe whether the Output has recovered.
+type CircuitBreaker struct {
+	Output   io.Writer
+	Fallback io.Writer
+	breaker
+}
+
+// NewCircuitBreaker returns a new CircuitBreaker writing to w.
+func NewCircuitBreaker(w io.Writer, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Output:  w,
+		breaker: breaker{threshold: threshold, cooldown: cooldown},
+	}
+}
+
+// Write writes p to the Output, or to the Fallback while open.
+func (c *CircuitBreaker) Write(p []byte) (int, error) {
+	if !c.allow() {
+		if c.Fallback != nil {
+			return c.Fallback.Write(p)
+		}
+		return len(p), nil
+	}
+	n, err := c.Output.Write(p)
+	c.done(err)
+	return n, err
+}
+
+// CircuitBreakerHook is a Hook that stops firing its Hook after Threshold
+// consecutive failures, like CircuitBreaker.
+type CircuitBreakerHook struct {
+	Hook     Hook
+	Fallback Hook
+	breaker
+}
+
+// NewCircuitBreakerHook returns a new CircuitBreakerHook firing hook.
+func NewCircuitBreakerHook(hook Hook, threshold int, cooldown time.Duration) *CircuitBreakerHook {
+	return &CircuitBreakerHook{
+		Hook:    hook,
+		breaker: breaker{threshold: threshold, cooldown: cooldown},
+	}
+}
+
+// Fire fires the Hook, or the Fallback while open.
+func (c *CircuitBreakerHook) Fire(e Entry) error {
+	if !c.allow() {
+		if c.Fallback != nil {
+			return c.Fallback.Fire(e)
+		}
+		return nil
+	}
+	err := c.Hook.Fire(e)
+	c.done(err)
+	return err
+}
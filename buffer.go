@@ -0,0 +1,64 @@
+package slogx
+
+import "sync"
+
+// Buffer is a Logger logging at DEBUG Level that holds back entries below
+// the Level of its parent until they are flushed or discarded.
+type Buffer struct {
+	*Logger
+	parent  *Logger
+	mutex   sync.Mutex
+	entries []Entry
+}
+
+// Buffer returns a new Buffer for the Logger. Entries the Logger would
+// log are written immediately.
+func (l *Logger) Buffer() *Buffer {
+	b := &Buffer{parent: l}
+	b.Logger = l.clone()
+	b.Logger.update(func(c *config) {
+		c.level = DEBUG
+		c.buffer = b
+	})
+	return b
+}
+
+// hold adds the Entry to the Buffer if its parent would not log it.
+func (b *Buffer) hold(e Entry) bool {
+	if b.parent.enabled(e.Level) {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.entries = append(b.entries, e)
+	return true
+}
+
+// Flush writes all held back entries regardless of the Level of the parent.
+func (b *Buffer) Flush() {
+	b.mutex.Lock()
+	entries := b.entries
+	b.entries = nil
+	b.mutex.Unlock()
+	for _, e := range entries {
+		b.parent.output(e)
+	}
+}
+
+// Discard drops all held back entries.
+func (b *Buffer) Discard() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.entries = nil
+}
+
+// DiscardOnSuccess flushes the held back entries if *err is not nil and
+// discards them otherwise. It is meant to be deferred with a pointer to a
+// named error result.
+func (b *Buffer) DiscardOnSuccess(err *error) {
+	if err != nil && *err != nil {
+		b.Flush()
+		return
+	}
+	b.Discard()
+}
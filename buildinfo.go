@@ -0,0 +1,16 @@
+package slogx
+
+import "runtime/debug"
+
+// buildVersion, buildCommit and buildTime are the values of the
+// ${version}, ${commit} and ${buildtime} verbs.
+var buildVersion, buildCommit, buildTime = readBuildInfo()
+
+func readBuildInfo() (string, string, string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", "", ""
+	}
+	commit, time := readVCSInfo(info)
+	return info.Main.Version, commit, time
+}
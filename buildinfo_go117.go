@@ -0,0 +1,10 @@
+//go:build !go1.18
+// +build !go1.18
+
+package slogx
+
+import "runtime/debug"
+
+func readVCSInfo(info *debug.BuildInfo) (string, string) {
+	return "", ""
+}
@@ -0,0 +1,25 @@
+//go:build go1.18
+// +build go1.18
+
+package slogx
+
+import "runtime/debug"
+
+func readVCSInfo(info *debug.BuildInfo) (string, string) {
+	var commit, time string
+	modified := false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			commit = s.Value
+		case "vcs.time":
+			time = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if modified && commit != "" {
+		commit += "-dirty"
+	}
+	return commit, time
+}
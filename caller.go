@@ -0,0 +1,41 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// callerInfo is the resolved call site of a log statement.
+type callerInfo struct {
+	path string
+	file string
+	line int
+}
+
+// callers caches the callerInfo of call sites by their program counters,
+// so repeated logging from the same call site skips resolving the frame.
+var callers sync.Map
+
+// caller returns the callerInfo of the caller skip frames above the caller
+// of caller, like runtime.Caller.
+func caller(skip int) callerInfo {
+	var pcs [2]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return callerInfo{}
+	}
+	if info, ok := callers.Load(pcs); ok {
+		return info.(callerInfo)
+	}
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	info := callerInfo{
+		path: frame.File,
+		file: filepath.Base(frame.File),
+		line: frame.Line,
+	}
+	callers.Store(pcs, info)
+	return info
+}
@@ -0,0 +1,31 @@
+//go:build tinygo
+// +build tinygo
+
+package slogx
+
+import (
+	"path/filepath"
+	"runtime"
+)
+
+// callerInfo is the resolved call site of a log statement.
+type callerInfo struct {
+	path string
+	file string
+	line int
+}
+
+// caller returns the callerInfo of the caller skip frames above the caller
+// of caller. Targets without caller information return an empty
+// callerInfo.
+func caller(skip int) callerInfo {
+	_, path, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return callerInfo{}
+	}
+	return callerInfo{
+		path: path,
+		file: filepath.Base(path),
+		line: line,
+	}
+}
@@ -0,0 +1,117 @@
+package slogx
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallSite is a source location that logged entries, with the number of
+// entries it logged.
+type CallSite struct {
+	File  string
+	Line  int
+	Count uint64
+}
+
+// String returns the CallSite as file:line.
+func (s CallSite) String() string {
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
+
+type siteKey struct {
+	file string
+	line int
+}
+
+// siteStats counts the entries written by call site.
+type siteStats struct {
+	counts sync.Map
+}
+
+// count records a written Entry if s is not nil.
+func (s *siteStats) count(e Entry) {
+	if s == nil {
+		return
+	}
+	key := siteKey{e.File, e.Line}
+	n, ok := s.counts.Load(key)
+	if !ok {
+		n, _ = s.counts.LoadOrStore(key, new(uint64))
+	}
+	atomic.AddUint64(n.(*uint64), 1)
+}
+
+// sites returns the call sites sorted by count, highest first.
+func (s *siteStats) sites() []CallSite {
+	var list []CallSite
+	s.counts.Range(func(k, n interface{}) bool {
+		key := k.(siteKey)
+		list = append(list, CallSite{key.file, key.line, atomic.LoadUint64(n.(*uint64))})
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].String() < list[j].String()
+	})
+	return list
+}
+
+// SetCallSiteTracking sets whether the entries written by the Logger are
+// counted by call site, to find noisy log statements to demote or sample.
+// The counts are returned by Stats.
+func (l *Logger) SetCallSiteTracking(enabled bool) {
+	l.update(func(c *config) {
+		if !enabled {
+			c.sites = nil
+			return
+		}
+		if c.sites == nil {
+			c.sites = &siteStats{}
+		}
+	})
+}
+
+// ReportNoisyCallSites enables call site tracking and logs the n call
+// sites that logged the most entries in the last interval, with their
+// count and rate, at the specified Level every interval until stop is
+// called. A non-positive interval only enables call site tracking.
+func (l *Logger) ReportNoisyCallSites(level Level, interval time.Duration, n int) (stop func()) {
+	l.SetCallSiteTracking(true)
+	last := make(map[string]uint64)
+	return every(interval, func() {
+		sites := l.config().sites
+		if sites == nil {
+			return
+		}
+		var noisy []CallSite
+		for _, s := range sites.sites() {
+			key := s.String()
+			count := s.Count - last[key]
+			last[key] = s.Count
+			if count > 0 {
+				noisy = append(noisy, CallSite{s.File, s.Line, count})
+			}
+		}
+		sort.SliceStable(noisy, func(i, j int) bool {
+			return noisy[i].Count > noisy[j].Count
+		})
+		if len(noisy) > n {
+			noisy = noisy[:n]
+		}
+		if !l.enabled(level) {
+			return
+		}
+		for _, s := range noisy {
+			l.log(level, Fields{
+				"site":  s.String(),
+				"count": s.Count,
+				"rate":  Rate(float64(s.Count) / interval.Seconds()),
+			}, "noisy call site")
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package slogx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var levelToCEFSeverity = map[Level]int{
+	DEBUG:   1,
+	INFO:    3,
+	WARNING: 5,
+	ERROR:   8,
+	FATAL:   10,
+}
+
+// cefFieldKeys maps the keys of entry Fields to CEF extension keys.
+var cefFieldKeys = map[string]string{
+	"remote_addr": "src",
+	"user":        "suser",
+	"method":      "requestMethod",
+	"path":        "request",
+	"user_agent":  "requestClientApplication",
+	"bytes":       "out",
+	"status":      "outcome",
+}
+
+// leefFieldKeys maps the keys of entry Fields to LEEF attribute keys.
+var leefFieldKeys = map[string]string{
+	"remote_addr": "src",
+	"user":        "usrName",
+	"bytes":       "dstBytes",
+}
+
+// CEFEncoder encodes entries in the ArcSight Common Event Format. The
+// signature ID is the logger name and the event name the message. Fields
+// are added as extensions, using the CEF key for common field names
+// (remote_addr as src, user as suser, ...).
+type CEFEncoder struct {
+	Vendor  string
+	Product string
+	Version string
+}
+
+// NewCEFEncoder returns a new CEFEncoder for the device.
+func NewCEFEncoder(vendor string, product string, version string) *CEFEncoder {
+	return &CEFEncoder{
+		Vendor:  vendor,
+		Product: product,
+		Version: version,
+	}
+}
+
+func newCEFEncoder(options map[string]string) (Encoder, error) {
+	return NewCEFEncoder(options["vendor"], options["product"], options["version"]), nil
+}
+
+// Encode returns the Entry as a CEF record.
+func (enc *CEFEncoder) Encode(e Entry) ([]byte, error) {
+	header := strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	value := strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|", header.Replace(enc.Vendor), header.Replace(enc.Product),
+		header.Replace(enc.Version), header.Replace(e.Name), header.Replace(e.Message), levelToCEFSeverity[e.Level])
+	fmt.Fprintf(&b, "rt=%d cat=%s", e.Time.UnixNano()/int64(time.Millisecond), e.Level.String())
+	if e.File != "" {
+		fmt.Fprintf(&b, " fname=%s cn1=%d cn1Label=line", value.Replace(e.File), e.Line)
+	}
+	fmt.Fprintf(&b, " msg=%s", value.Replace(e.Message))
+	for _, k := range e.Fields.sortedKeys() {
+		key := cefFieldKeys[k]
+		if key == "" {
+			key = k
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value.Replace(fmt.Sprint(e.Fields[k])))
+	}
+	return []byte(b.String()), nil
+}
+
+// LEEFEncoder encodes entries in the IBM QRadar Log Event Extended Format
+// 2.0 with tab delimited attributes. The event ID is the logger name.
+type LEEFEncoder struct {
+	Vendor  string
+	Product string
+	Version string
+}
+
+// NewLEEFEncoder returns a new LEEFEncoder for the device.
+func NewLEEFEncoder(vendor string, product string, version string) *LEEFEncoder {
+	return &LEEFEncoder{
+		Vendor:  vendor,
+		Product: product,
+		Version: version,
+	}
+}
+
+func newLEEFEncoder(options map[string]string) (Encoder, error) {
+	return NewLEEFEncoder(options["vendor"], options["product"], options["version"]), nil
+}
+
+// Encode returns the Entry as a LEEF record.
+func (enc *LEEFEncoder) Encode(e Entry) ([]byte, error) {
+	header := strings.NewReplacer(`|`, " ", "\n", " ", "\r", " ")
+	value := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	var b strings.Builder
+	fmt.Fprintf(&b, "LEEF:2.0|%s|%s|%s|%s|\t|", header.Replace(enc.Vendor), header.Replace(enc.Product),
+		header.Replace(enc.Version), header.Replace(e.Name))
+	fmt.Fprintf(&b, "devTime=%d\tdevTimeFormat=epoch\tsev=%d\tcat=%s\tmsg=%s",
+		e.Time.UnixNano()/int64(time.Millisecond), levelToCEFSeverity[e.Level], e.Level.String(), value.Replace(e.Message))
+	for _, k := range e.Fields.sortedKeys() {
+		key := leefFieldKeys[k]
+		if key == "" {
+			key = k
+		}
+		fmt.Fprintf(&b, "\t%s=%s", key, value.Replace(fmt.Sprint(e.Fields[k])))
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,63 @@
+package slogx
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	classes      atomic.Value
+	classesMutex sync.Mutex
+)
+
+// ClassifyField tags a field key with classes, such as pii, internal or a
+// retention period like 30d, for all loggers. Entries carrying classified
+// fields get the sorted classes of their fields as the field classes, so
+// encoders emit them as metadata and a Route can copy them to a restricted
+// output by Class.
+func ClassifyField(key string, tags ...string) {
+	classesMutex.Lock()
+	defer classesMutex.Unlock()
+	current, _ := classes.Load().(map[string][]string)
+	next := make(map[string][]string, len(current)+1)
+	for k, v := range current {
+		next[k] = v
+	}
+	next[key] = append(append([]string(nil), next[key]...), tags...)
+	classes.Store(next)
+}
+
+// classify returns the sorted classes of the fields of the Entry, or nil
+// if none of its fields are classified.
+func classify(e Entry) []string {
+	current, _ := classes.Load().(map[string][]string)
+	if len(current) == 0 {
+		return nil
+	}
+	set := make(map[string]bool)
+	for k := range e.Fields {
+		for _, class := range current[k] {
+			set[class] = true
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(set))
+	for class := range set {
+		list = append(list, class)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func hasClass(e Entry, class string) bool {
+	list, _ := e.Fields["classes"].([]string)
+	for _, c := range list {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
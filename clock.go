@@ -0,0 +1,30 @@
+package slogx
+
+import "time"
+
+// SetClock sets the function returning the time of entries, e.g. a fixed
+// time for deterministic output in tests. Nil restores time.Now.
+func (l *Logger) SetClock(clock func() time.Time) {
+	l.update(func(c *config) {
+		c.clock = clock
+	})
+}
+
+// WithTime returns a copy of the Logger whose entries have the time t, for
+// emitting historical entries with their original time. The copy is not
+// registered.
+func (l *Logger) WithTime(t time.Time) *Logger {
+	c := l.clone()
+	c.SetClock(func() time.Time {
+		return t
+	})
+	return c
+}
+
+// now returns the time of an Entry.
+func (c *config) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
@@ -0,0 +1,52 @@
+//go:build !tinygo
+// +build !tinygo
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/IchBinLeoon/slogx"
+)
+
+// decrypt runs the decrypt command, writing the decrypted output of an
+// EncryptWriter to stdout.
+func decrypt(args []string) {
+	fs := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	keyFile := fs.String("key", "", "PEM-encoded RSA private key file")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: slogx decrypt -key file [file]\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *keyFile == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	key, err := os.ReadFile(*keyFile)
+	if err != nil {
+		fatal(err)
+	}
+	var r io.Reader = os.Stdin
+	if fs.NArg() > 0 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+	out := bufio.NewWriter(os.Stdout)
+	err = slogx.Decrypt(out, bufio.NewReader(r), key)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,158 @@
+//go:build !tinygo
+// +build !tinygo
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IchBinLeoon/slogx"
+)
+
+// fieldFlag is a repeatable key=value flag.
+type fieldFlag map[string]string
+
+func (f fieldFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for k, v := range f {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f fieldFlag) Set(s string) error {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("invalid field '%s'", s)
+	}
+	f[kv[0]] = kv[1]
+	return nil
+}
+
+// match reports whether the Entry has all fields with the formatted values.
+func (f fieldFlag) match(e slogx.Entry) bool {
+	for k, v := range f {
+		value, ok := e.Fields[k]
+		if !ok || fmt.Sprint(value) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFunc parses a line into an Entry and returns the kind of the line,
+// or an empty kind if it is not an entry.
+type parseFunc func(line string) (slogx.Entry, string)
+
+// newInput returns a parseFunc for lines of the input kind: text lines of
+// the format, JSON lines written by a JSONEncoder, logfmt lines, or auto to
+// detect the kind of every line.
+func newInput(kind, format, timeFormat string) (parseFunc, error) {
+	jsonParser := slogx.NewJSONParser()
+	parseJSON := func(line string) (slogx.Entry, string) {
+		e, err := jsonParser.Parse(line)
+		if err != nil {
+			return e, ""
+		}
+		return e, "json"
+	}
+	switch kind {
+	case "json":
+		return parseJSON, nil
+	case "logfmt":
+		return parseLogfmt, nil
+	case "text", "auto":
+	default:
+		return nil, fmt.Errorf("invalid input '%s'", kind)
+	}
+
+	textParser, err := slogx.NewParser(format, timeFormat)
+	if err != nil {
+		return nil, err
+	}
+	parseText := func(line string) (slogx.Entry, string) {
+		e, err := textParser.Parse(line)
+		if err != nil {
+			return e, ""
+		}
+		return e, "text"
+	}
+	if kind == "text" {
+		return parseText, nil
+	}
+	return func(line string) (slogx.Entry, string) {
+		if strings.HasPrefix(line, "{") {
+			if e, kind := parseJSON(line); kind != "" {
+				return e, kind
+			}
+		}
+		if e, kind := parseText(line); kind != "" {
+			return e, kind
+		}
+		return parseLogfmt(line)
+	}, nil
+}
+
+// parseLogfmt parses a logfmt line of key=value pairs. The keys time or ts,
+// level or lvl, msg or message, and logger or name set the Entry, all
+// others are added as Fields. Lines without a level or message are not
+// entries.
+func parseLogfmt(line string) (slogx.Entry, string) {
+	var e slogx.Entry
+	rest := strings.TrimSpace(line)
+	for rest != "" {
+		eq := strings.IndexAny(rest, "= ")
+		if eq <= 0 || rest[eq] != '=' {
+			return slogx.Entry{}, ""
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return slogx.Entry{}, ""
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		rest = strings.TrimLeft(rest, " ")
+
+		switch key {
+		case "time", "ts":
+			if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				e.Time = t
+				continue
+			}
+		case "level", "lvl":
+			if level, err := slogx.ParseLevelE(value); err == nil {
+				e.Level = level
+				continue
+			}
+		case "msg", "message":
+			e.Message = value
+			continue
+		case "logger", "name":
+			e.Name = value
+			continue
+		}
+		if e.Fields == nil {
+			e.Fields = slogx.Fields{}
+		}
+		e.Fields[key] = value
+	}
+	if e.Level == slogx.NONE && e.Message == "" {
+		return slogx.Entry{}, ""
+	}
+	return e, "logfmt"
+}
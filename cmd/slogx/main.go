@@ -0,0 +1,229 @@
+//go:build !tinygo
+// +build !tinygo
+
+// Command slogx pretty-prints slogx log output with colors.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IchBinLeoon/slogx"
+)
+
+var levelToColor = map[slogx.Level]string{
+	slogx.FATAL:   "\033[1;35m",
+	slogx.ERROR:   "\033[31m",
+	slogx.WARNING: "\033[33m",
+	slogx.INFO:    "\033[32m",
+	slogx.DEBUG:   "\033[36m",
+}
+
+const colorReset = "\033[0m"
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "decrypt":
+			decrypt(os.Args[2:])
+			return
+		case "relay":
+			relay(os.Args[2:])
+			return
+		case "replay":
+			replay(os.Args[2:])
+			return
+		}
+	}
+
+	level := flag.String("level", "DEBUG", "show only entries at or above this level")
+	follow := flag.Bool("f", false, "follow the file as it grows and is rotated")
+	noColor := flag.Bool("no-color", false, "disable colored output")
+	format := flag.String("format", slogx.DefaultFormat, "format of the input lines")
+	timeFormat := flag.String("time-format", slogx.DefaultTimeFormat, "time format of the input lines")
+	render := flag.String("render", "", "format to render entries with")
+	input := flag.String("input", "auto", "kind of the input lines: auto, text, json or logfmt")
+	fields := fieldFlag{}
+	flag.Var(fields, "field", "show only entries with the field `key=value`, repeatable")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: slogx [flags] [file]\n       slogx decrypt -key file [file]\n       slogx relay -to url [flags] [file...]\n       slogx replay -to url [flags] [file...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	minLevel, err := slogx.ParseLevelE(*level)
+	if err != nil {
+		fatal(fmt.Errorf("invalid level '%s'", *level))
+	}
+	p := &printer{
+		level:    minLevel,
+		color:    !*noColor,
+		out:      bufio.NewWriter(os.Stdout),
+		layout:   *timeFormat,
+		fields:   fields,
+		rerender: *render != "",
+	}
+	if p.parse, err = newInput(*input, *format, *timeFormat); err != nil {
+		fatal(err)
+	}
+	if *render == "" {
+		*render = *format
+	}
+	if p.render, err = slogx.CompileFormat(*render); err != nil {
+		fatal(err)
+	}
+
+	switch {
+	case flag.NArg() == 0:
+		err = p.copy(os.Stdin)
+	case *follow:
+		err = p.follow(flag.Arg(0))
+	default:
+		var f *os.File
+		f, err = os.Open(flag.Arg(0))
+		if err == nil {
+			err = p.copy(f)
+			f.Close()
+		}
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "slogx: %v\n", err)
+	os.Exit(1)
+}
+
+type printer struct {
+	level    slogx.Level
+	color    bool
+	out      *bufio.Writer
+	layout   string
+	parse    parseFunc
+	fields   fieldFlag
+	render   slogx.Format
+	rerender bool
+}
+
+// copy prints every line of r until EOF.
+func (p *printer) copy(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		p.print(s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	return p.out.Flush()
+}
+
+// follow prints the file at path and keeps printing lines appended to it.
+// The file is reopened when it is truncated or replaced by rotation.
+func (p *printer) follow(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+	}()
+
+	r := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := r.ReadString('\n')
+		partial += line
+		if err == nil {
+			p.print(strings.TrimSuffix(partial, "\n"))
+			partial = ""
+			continue
+		}
+		if err != io.EOF {
+			return err
+		}
+		if err := p.out.Flush(); err != nil {
+			return err
+		}
+		time.Sleep(250 * time.Millisecond)
+
+		rotated, err := isRotated(f, path)
+		if err != nil || !rotated {
+			continue
+		}
+		nf, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		f.Close()
+		f = nf
+		r.Reset(f)
+		partial = ""
+	}
+}
+
+// isRotated reports whether the file at path is no longer the open file f,
+// or whether f has been truncated below the current read offset.
+func isRotated(f *os.File, path string) (bool, error) {
+	current, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	latest, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if !os.SameFile(current, latest) {
+		return true, nil
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	return latest.Size() < offset, nil
+}
+
+// print writes a single line, skipping it if its level or fields are
+// filtered out. JSON and logfmt entries are rendered with the render
+// format, and text entries only if it is set.
+func (p *printer) print(line string) {
+	e, kind := p.parse(line)
+	if len(p.fields) > 0 && (kind == "" || !p.fields.match(e)) {
+		return
+	}
+	if kind != "" && (p.rerender || kind != "text") {
+		line = p.render.Render(e, p.layout)
+	}
+	level, start, end := findLevel(line)
+	if kind != "" && e.Level != slogx.NONE {
+		level = e.Level
+	}
+	if level != slogx.NONE && level > p.level {
+		return
+	}
+	if p.color && level != slogx.NONE {
+		line = line[:start] + levelToColor[level] + line[start:end] + colorReset + line[end:]
+	}
+	fmt.Fprintln(p.out, line)
+}
+
+// findLevel returns the first level name in line and its position.
+func findLevel(line string) (slogx.Level, int, int) {
+	start := 0
+	for _, field := range strings.Fields(line) {
+		start += strings.Index(line[start:], field)
+		name := strings.Trim(field, "[]():")
+		if level := slogx.ParseLevel(name); level != slogx.NONE && name == level.String() {
+			offset := strings.Index(field, name)
+			return level, start + offset, start + offset + len(name)
+		}
+		start += len(field)
+	}
+	return slogx.NONE, 0, 0
+}
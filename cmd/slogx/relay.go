@@ -0,0 +1,129 @@
+//go:build !tinygo
+// +build !tinygo
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/IchBinLeoon/slogx"
+)
+
+// relay runs the relay command, forwarding the lines of the files or stdin
+// to a sink.
+func relay(args []string) {
+	fs := flag.NewFlagSet("relay", flag.ExitOnError)
+	to := fs.String("to", "", "URL of the sink to forward to")
+	encoder := fs.String("encoder", "", "encoder to re-encode parsed entries with")
+	format := fs.String("format", slogx.DefaultFormat, "format of the input lines")
+	timeFormat := fs.String("time-format", slogx.DefaultTimeFormat, "time format of the input lines")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: slogx relay -to url [flags] [file...]\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *to == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	forward, err := newForwarder(*to, *encoder, *format, *timeFormat)
+	if err != nil {
+		fatal(err)
+	}
+
+	if fs.NArg() == 0 {
+		if err := forwardLines(os.Stdin, forward); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	for _, path := range fs.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			fatal(err)
+		}
+		err = forwardLines(f, forward)
+		f.Close()
+		if err != nil {
+			fatal(err)
+		}
+	}
+}
+
+// newForwarder returns a function forwarding lines to the sink at the URL,
+// unchanged or, with an encoder, parsed and re-encoded.
+func newForwarder(to, encoder, format, timeFormat string) (func(line string) error, error) {
+	w := &sinkWriter{url: to}
+	if encoder == "" {
+		return func(line string) error {
+			_, err := w.Write([]byte(line + "\n"))
+			return err
+		}, nil
+	}
+	enc, err := slogx.NewEncoder(encoder, nil)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := slogx.NewParser(format, timeFormat)
+	if err != nil {
+		return nil, err
+	}
+	logger := slogx.NewLogger("relay")
+	logger.SetLevel(slogx.DEBUG)
+	logger.SetOutput(w)
+	logger.SetEncoder(enc)
+	return func(line string) error {
+		e, err := parser.Parse(line)
+		if err != nil {
+			e = slogx.Entry{Time: time.Now(), Level: slogx.INFO, Name: "relay", Message: line}
+		}
+		logger.LogEntry(e)
+		return w.err
+	}, nil
+}
+
+// forwardLines forwards every line of r until EOF.
+func forwardLines(r io.Reader, forward func(line string) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		if err := forward(s.Text()); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// sinkWriter writes to a sink opened from its URL, reopening it once when
+// a write fails, e.g. after a connection was closed.
+type sinkWriter struct {
+	url string
+	w   io.Writer
+	err error
+}
+
+func (s *sinkWriter) Write(p []byte) (int, error) {
+	for attempt := 0; ; attempt++ {
+		if s.w == nil {
+			if s.w, s.err = slogx.OpenSink(s.url); s.err != nil {
+				s.w = nil
+				return 0, s.err
+			}
+		}
+		n, err := s.w.Write(p)
+		if err == nil || attempt > 0 {
+			s.err = err
+			return n, err
+		}
+		if c, ok := s.w.(io.Closer); ok {
+			c.Close()
+		}
+		s.w = nil
+	}
+}
@@ -0,0 +1,118 @@
+//go:build !tinygo
+// +build !tinygo
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IchBinLeoon/slogx"
+)
+
+// replay runs the replay command, forwarding the lines of the files or
+// stdin to a sink with their recorded timing scaled by the speed.
+func replay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	to := fs.String("to", "", "URL of the sink to forward to")
+	speed := fs.String("speed", "1x", "speed factor like 10x, or max for no delays")
+	encoder := fs.String("encoder", "", "encoder to re-encode parsed entries with")
+	format := fs.String("format", slogx.DefaultFormat, "format of the input lines")
+	timeFormat := fs.String("time-format", slogx.DefaultTimeFormat, "time format of the input lines")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: slogx replay -to url [flags] [file...]\n")
+		fs.PrintDefaults()
+	}
+	files := parseInterspersed(fs, args)
+	if *to == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	factor, err := parseSpeed(*speed)
+	if err != nil {
+		fatal(err)
+	}
+	parser, err := slogx.NewParser(*format, *timeFormat)
+	if err != nil {
+		fatal(err)
+	}
+	forward, err := newForwarder(*to, *encoder, *format, *timeFormat)
+	if err != nil {
+		fatal(err)
+	}
+
+	r := &replayer{parser: parser, speed: factor, forward: forward}
+	if len(files) == 0 {
+		if err := forwardLines(os.Stdin, r.replay); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			fatal(err)
+		}
+		err = forwardLines(f, r.replay)
+		f.Close()
+		if err != nil {
+			fatal(err)
+		}
+	}
+}
+
+// parseInterspersed parses the flags of args, which may also follow the
+// positional arguments, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseSpeed returns the factor of a speed like 10x or 0.5, or 0 for max.
+func parseSpeed(s string) (float64, error) {
+	if s == "max" {
+		return 0, nil
+	}
+	factor, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil || factor <= 0 {
+		return 0, fmt.Errorf("invalid speed '%s'", s)
+	}
+	return factor, nil
+}
+
+// replayer forwards lines at the times of their entries relative to the
+// first entry, scaled by the speed.
+type replayer struct {
+	parser  *slogx.Parser
+	speed   float64
+	forward func(line string) error
+	first   time.Time
+	start   time.Time
+}
+
+// replay waits until the time of the entry of the line and forwards it.
+// Lines without an entry are forwarded immediately.
+func (r *replayer) replay(line string) error {
+	if e, err := r.parser.Parse(line); err == nil && r.speed > 0 {
+		if r.start.IsZero() {
+			r.first, r.start = e.Time, time.Now()
+		}
+		offset := time.Duration(float64(e.Time.Sub(r.first)) / r.speed)
+		if wait := time.Until(r.start.Add(offset)); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+	return r.forward(line)
+}
@@ -0,0 +1,123 @@
+package slogx
+
+import (
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// CoalescedWriter is an io.Writer shared by loggers writing to the same
+// Output. It buffers whole records and writes them together, so records
+// of different loggers never interleave and fewer writes are made. The
+// buffer is written when it would exceed Size, Interval after the first
+// buffered record, and by Flush.
+type CoalescedWriter struct {
+	Output   io.Writer
+	Size     int
+	Interval time.Duration
+	Mutex    sync.Mutex
+	buf      []byte
+	timer    *time.Timer
+}
+
+var (
+	coalesced      = make(map[io.Writer]*CoalescedWriter)
+	coalescedMutex sync.Mutex
+)
+
+// Coalesce returns the CoalescedWriter of w, creating it with a Size of
+// 64 KiB and an Interval of 100ms if it does not exist, so all loggers
+// coalescing the same writer share it. Writers that are not comparable get
+// a new CoalescedWriter on every call, and a nil writer one that discards
+// all records.
+func Coalesce(w io.Writer) *CoalescedWriter {
+	if w == nil {
+		return newCoalescedWriter(io.Discard)
+	}
+	if !reflect.TypeOf(w).Comparable() {
+		return newCoalescedWriter(w)
+	}
+	coalescedMutex.Lock()
+	defer coalescedMutex.Unlock()
+	c, ok := coalesced[w]
+	if !ok {
+		c = newCoalescedWriter(w)
+		coalesced[w] = c
+	}
+	return c
+}
+
+func newCoalescedWriter(w io.Writer) *CoalescedWriter {
+	return &CoalescedWriter{
+		Output:   w,
+		Size:     64 << 10,
+		Interval: 100 * time.Millisecond,
+	}
+}
+
+// Write buffers p as a single record. Records larger than Size are written
+// directly after the buffer.
+func (w *CoalescedWriter) Write(p []byte) (int, error) {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	if len(w.buf)+len(p) > w.Size {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) >= w.Size {
+		return w.Output.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.Interval, func() {
+			if err := w.Flush(); err != nil {
+				diagnose(ERROR, Fields{"error": err}, "flush failed")
+			}
+		})
+	}
+	return len(p), nil
+}
+
+// Flush writes the buffered records.
+func (w *CoalescedWriter) Flush() error {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	return w.flush()
+}
+
+func (w *CoalescedWriter) flush() error {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.Output.Write(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+// Close writes the buffered records, closes the Output if it implements
+// io.Closer and removes the CoalescedWriter, so Coalesce returns a new one
+// for the Output.
+func (w *CoalescedWriter) Close() error {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	if w.Output != nil && reflect.TypeOf(w.Output).Comparable() {
+		coalescedMutex.Lock()
+		if coalesced[w.Output] == w {
+			delete(coalesced, w.Output)
+		}
+		coalescedMutex.Unlock()
+	}
+	err := w.flush()
+	if c, ok := w.Output.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
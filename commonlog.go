@@ -0,0 +1,61 @@
+package slogx
+
+import "fmt"
+
+// AccessLogEncoder encodes entries logged by AccessLog in the Common Log
+// Format, or in the Combined Log Format if Combined is set.
+type AccessLogEncoder struct {
+	Combined bool
+}
+
+// NewCommonLogEncoder returns a new AccessLogEncoder for the Common Log
+// Format.
+func NewCommonLogEncoder() *AccessLogEncoder {
+	return &AccessLogEncoder{}
+}
+
+// NewCombinedLogEncoder returns a new AccessLogEncoder for the Combined Log
+// Format.
+func NewCombinedLogEncoder() *AccessLogEncoder {
+	return &AccessLogEncoder{
+		Combined: true,
+	}
+}
+
+func newCommonLogEncoder(options map[string]string) (Encoder, error) {
+	return NewCommonLogEncoder(), nil
+}
+
+func newCombinedLogEncoder(options map[string]string) (Encoder, error) {
+	return NewCombinedLogEncoder(), nil
+}
+
+// Encode returns the Entry as an access log line. Missing fields are
+// written as "-".
+func (enc *AccessLogEncoder) Encode(e Entry) ([]byte, error) {
+	field := func(key string) string {
+		v, ok := e.Fields[key]
+		if !ok {
+			return "-"
+		}
+		s := fmt.Sprint(v)
+		if s == "" || s == "0" && key == "bytes" {
+			return "-"
+		}
+		return s
+	}
+	request := fmt.Sprintf("%s %s", field("method"), field("path"))
+	if q, ok := e.Fields["query"]; ok && q != "" {
+		request += fmt.Sprintf("?%v", q)
+	}
+	if proto, ok := e.Fields["proto"]; ok {
+		request += fmt.Sprintf(" %v", proto)
+	}
+	line := fmt.Sprintf("%s - %s [%s] %q %s %s",
+		field("remote_addr"), field("user"), e.Time.Format("02/Jan/2006:15:04:05 -0700"),
+		request, field("status"), field("bytes"))
+	if enc.Combined {
+		line += fmt.Sprintf(" %q %q", field("referer"), field("user_agent"))
+	}
+	return []byte(line), nil
+}
@@ -0,0 +1,230 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProfileEnv is the environment variable naming the profile LoadConfig
+// applies.
+const ProfileEnv = "SLOGX_PROFILE"
+
+// Config configures loggers by name, e.g. from a checked-in JSON file
+// covering all environments. Its Loggers are the base of every profile.
+type Config struct {
+	Loggers  map[string]LoggerConfig `json:"loggers"`
+	Profiles map[string]Profile      `json:"profiles"`
+}
+
+// Profile is a named set of logger settings, such as development, staging
+// or production. It inherits the settings of the profile it Extends, or of
+// the base Loggers, and overrides them by setting.
+type Profile struct {
+	Extends string                  `json:"extends"`
+	Loggers map[string]LoggerConfig `json:"loggers"`
+}
+
+// LoggerConfig are the settings of a Logger. Unset settings are inherited
+// or left unchanged. Output is a sink URL opened with OpenSink, and Encoder
+// the name of a registered encoder created with EncoderOptions.
+type LoggerConfig struct {
+	Level          *Level            `json:"level"`
+	Format         string            `json:"format"`
+	TimeFormat     string            `json:"time_format"`
+	Output         string            `json:"output"`
+	Encoder        string            `json:"encoder"`
+	EncoderOptions map[string]string `json:"encoder_options"`
+	Development    *bool             `json:"development"`
+}
+
+var (
+	loadedConfig  *Config
+	configOutputs = make(map[*Logger]configOutput)
+	configMutex   sync.Mutex
+)
+
+// configOutput is an output opened for a Logger by a Config.
+type configOutput struct {
+	url    string
+	writer io.Writer
+}
+
+// LoadConfig loads the JSON Config at the path and applies the profile
+// named by the SLOGX_PROFILE environment variable, or only the base
+// Loggers if it is not set. ApplyProfile switches to another profile of
+// the loaded Config.
+func LoadConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("slogx: %v", err)
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("slogx: %v", err)
+	}
+	configMutex.Lock()
+	loadedConfig = &c
+	configMutex.Unlock()
+	return c.Apply(os.Getenv(ProfileEnv))
+}
+
+// ApplyProfile applies the profile of the Config loaded with LoadConfig.
+func ApplyProfile(name string) error {
+	configMutex.Lock()
+	c := loadedConfig
+	configMutex.Unlock()
+	if c == nil {
+		return fmt.Errorf("slogx: no config loaded")
+	}
+	return c.Apply(name)
+}
+
+// Apply configures the loggers with the settings of the profile, creating
+// loggers that are not registered. An empty name applies the base Loggers.
+func (c *Config) Apply(name string) error {
+	settings, err := c.Resolve(name)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(settings))
+	for n := range settings {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		l := GetLogger(n)
+		if l == nil {
+			l = NewLogger(n)
+		}
+		if err := settings[n].apply(l); err != nil {
+			return fmt.Errorf("slogx: logger '%s': %s", n, strings.TrimPrefix(err.Error(), "slogx: "))
+		}
+	}
+	return nil
+}
+
+// Resolve returns the settings of the loggers in the profile, merged with
+// those of the profiles it extends and the base Loggers.
+func (c *Config) Resolve(name string) (map[string]LoggerConfig, error) {
+	var chain []Profile
+	seen := make(map[string]bool)
+	for name != "" {
+		if seen[name] {
+			return nil, fmt.Errorf("slogx: cyclic profile '%s'", name)
+		}
+		seen[name] = true
+		p, ok := c.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("slogx: unknown profile '%s'", name)
+		}
+		chain = append(chain, p)
+		name = p.Extends
+	}
+
+	settings := make(map[string]LoggerConfig)
+	for n, lc := range c.Loggers {
+		settings[n] = lc
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for n, lc := range chain[i].Loggers {
+			settings[n] = settings[n].merge(lc)
+		}
+	}
+	return settings, nil
+}
+
+// merge returns the settings overridden by the set settings of o.
+func (lc LoggerConfig) merge(o LoggerConfig) LoggerConfig {
+	if o.Level != nil {
+		lc.Level = o.Level
+	}
+	if o.Format != "" {
+		lc.Format = o.Format
+	}
+	if o.TimeFormat != "" {
+		lc.TimeFormat = o.TimeFormat
+	}
+	if o.Output != "" {
+		lc.Output = o.Output
+	}
+	if o.Encoder != "" {
+		lc.Encoder = o.Encoder
+		lc.EncoderOptions = o.EncoderOptions
+	}
+	if o.Development != nil {
+		lc.Development = o.Development
+	}
+	return lc
+}
+
+func (lc LoggerConfig) apply(l *Logger) error {
+	if lc.Format != "" {
+		if err := l.SetFormat(lc.Format); err != nil {
+			return err
+		}
+	}
+	if lc.Output != "" {
+		if err := applyOutput(l, lc.Output); err != nil {
+			return err
+		}
+	}
+	if lc.Encoder != "" {
+		enc, err := NewEncoder(lc.Encoder, lc.EncoderOptions)
+		if err != nil {
+			return err
+		}
+		l.SetEncoder(enc)
+	}
+	if lc.Level != nil {
+		l.SetLevel(*lc.Level)
+	}
+	if lc.TimeFormat != "" {
+		l.SetTimeFormat(lc.TimeFormat)
+	}
+	if lc.Development != nil {
+		l.SetDevelopment(*lc.Development)
+	}
+	return nil
+}
+
+// applyOutput sets the output of the Logger to the sink URL. The output is
+// kept if the URL is unchanged, otherwise the output previously opened for
+// the Logger is closed.
+func applyOutput(l *Logger, rawurl string) error {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	prev, ok := configOutputs[l]
+	if ok && prev.url == rawurl && sameWriter(l.config().output, prev.writer) {
+		return nil
+	}
+	w, err := OpenSink(rawurl)
+	if err != nil {
+		return err
+	}
+	l.SetOutput(w)
+	configOutputs[l] = configOutput{url: rawurl, writer: w}
+	if !ok || sameWriter(prev.writer, w) {
+		return nil
+	}
+	if err := closeSink(prev.writer); err != nil {
+		diagnose(WARNING, Fields{"error": err, "logger": l.config().name}, "closing previous output failed")
+	}
+	return nil
+}
+
+// sameWriter reports whether a and b are the same writer.
+func sameWriter(a, b io.Writer) bool {
+	if a == nil || b == nil || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
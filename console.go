@@ -0,0 +1,104 @@
+package slogx
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// ConsoleWriter is an io.Writer that fits lines to the width of a terminal.
+// Longer lines are truncated, or wrapped with continuation lines starting
+// with Indent if Wrap is set. If Width is 0 it is detected from the Output
+// on every write, and lines are left untouched when the Output is not a
+// terminal.
+type ConsoleWriter struct {
+	Output io.Writer
+	Width  int
+	Wrap   bool
+	Indent string
+}
+
+// NewConsoleWriter returns a new ConsoleWriter writing to w.
+func NewConsoleWriter(w io.Writer) *ConsoleWriter {
+	return &ConsoleWriter{
+		Output: w,
+		Indent: "    ",
+	}
+}
+
+// Write fits each line of p to the width and writes it to the Output.
+func (c *ConsoleWriter) Write(p []byte) (int, error) {
+	width := c.Width
+	if width == 0 {
+		if f, ok := c.Output.(*os.File); ok {
+			width = terminalWidth(f)
+		}
+	}
+	if width <= 0 {
+		return c.Output.Write(p)
+	}
+
+	var b bytes.Buffer
+	lines := strings.SplitAfter(string(p), "\n")
+	for _, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		if c.Wrap {
+			c.wrap(&b, text, width)
+		} else {
+			b.WriteString(truncateWidth(text, width))
+		}
+		if len(text) < len(line) {
+			b.WriteString("\n")
+		}
+	}
+	if _, err := c.Output.Write(b.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// wrap writes text to b in lines of at most width runes, preferring to
+// break at spaces.
+func (c *ConsoleWriter) wrap(b *bytes.Buffer, text string, width int) {
+	indent := c.Indent
+	if utf8.RuneCountInString(indent) >= width {
+		indent = ""
+	}
+	prefix := ""
+	for {
+		limit := width - utf8.RuneCountInString(prefix)
+		if utf8.RuneCountInString(text) <= limit {
+			b.WriteString(prefix + text)
+			return
+		}
+		cut := runeOffset(text, limit)
+		if i := strings.LastIndexByte(text[:cut], ' '); i > 0 {
+			cut = i
+		}
+		b.WriteString(prefix + text[:cut] + "\n")
+		text = strings.TrimLeft(text[cut:], " ")
+		prefix = indent
+	}
+}
+
+// truncateWidth returns text cut to width runes, ending with an ellipsis
+// if it was cut.
+func truncateWidth(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	return text[:runeOffset(text, width-1)] + ellipsis
+}
+
+// runeOffset returns the byte offset of the nth rune of s.
+func runeOffset(s string, n int) int {
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
@@ -0,0 +1,57 @@
+//go:build js && wasm
+// +build js,wasm
+
+package slogx
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+var levelToConsoleMethod = map[Level]string{
+	DEBUG:   "debug",
+	INFO:    "info",
+	WARNING: "warn",
+	ERROR:   "error",
+	FATAL:   "error",
+}
+
+// ConsoleHook is a Hook that writes entries to the browser console with
+// the console method of their Level. The Fields are passed as an object,
+// so they can be inspected in the developer tools.
+type ConsoleHook struct {
+	console js.Value
+}
+
+// NewConsoleHook returns a new ConsoleHook.
+func NewConsoleHook() *ConsoleHook {
+	return &ConsoleHook{
+		console: js.Global().Get("console"),
+	}
+}
+
+// Fire writes the logger name and message of the Entry, followed by its
+// Fields if there are any.
+func (h *ConsoleHook) Fire(e Entry) error {
+	args := []interface{}{e.Name + ": " + e.Message}
+	if len(e.Fields) > 0 {
+		fields := make(map[string]interface{}, len(e.Fields))
+		for k, v := range e.Fields {
+			fields[k] = consoleValue(v)
+		}
+		args = append(args, fields)
+	}
+	h.console.Call(levelToConsoleMethod[e.Level], args...)
+	return nil
+}
+
+// consoleValue returns v as a value accepted by js.ValueOf.
+func consoleValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, bool, string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case error:
+		return v.Error()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,102 @@
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+// SetCrashDump sets whether a goroutine dump and memory statistics are
+// captured before the Logger exits in Fatal.
+func (l *Logger) SetCrashDump(enabled bool) {
+	l.update(func(c *config) {
+		c.crashDump = enabled
+	})
+}
+
+// SetCrashFile sets the file the crash dump is appended to. If no file is
+// set, the crash dump is logged at FATAL Level.
+func (l *Logger) SetCrashFile(path string) {
+	l.update(func(c *config) {
+		c.crashFile = path
+	})
+}
+
+// SetFatalExitCode sets the exit code of the Fatal methods. The default is
+// 1.
+func (l *Logger) SetFatalExitCode(code int) {
+	l.update(func(c *config) {
+		c.exitCode = code
+	})
+}
+
+// exit exits with the code, or the exit code of the Logger if code is
+// zero.
+func (l *Logger) exit(code int) {
+	c := l.config()
+	if code == 0 {
+		code = c.exitCode
+	}
+	if c.crashDump {
+		l.dumpCrash(c.crashFile)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	if err := shutdown(ctx, true); err != nil {
+		l.diagnose(ERROR, Fields{"error": err}, "shutdown failed")
+	}
+	cancel()
+	os.Exit(code)
+}
+
+func (l *Logger) dumpCrash(path string) {
+	buf := goroutineDump()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	fields := Fields{
+		"heap_alloc":   m.HeapAlloc,
+		"heap_sys":     m.HeapSys,
+		"heap_objects": m.HeapObjects,
+		"gc_count":     m.NumGC,
+		"goroutines":   runtime.NumGoroutine(),
+	}
+
+	if path == "" {
+		site := caller(3)
+		l.output(Entry{
+			Time:    l.config().now(),
+			Level:   FATAL,
+			File:    site.file,
+			Line:    site.line,
+			Name:    l.GetName(),
+			Message: "crash dump\n" + string(buf),
+			Fields:  fields,
+		})
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		l.diagnose(ERROR, Fields{"error": err}, "crash dump failed")
+		return
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%s crash dump of %s:%s\n\n%s\n", time.Now().Format(time.RFC3339), l.GetName(), fields, buf)
+	if err != nil {
+		l.diagnose(ERROR, Fields{"error": err}, "crash dump failed")
+	}
+}
+
+// goroutineDump returns the stack traces of all goroutines.
+func goroutineDump() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	return buf
+}
@@ -0,0 +1,100 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCSVColumns are the columns written by a CSVEncoder by default.
+var DefaultCSVColumns = []string{"time", "level", "name", "message"}
+
+// CSVEncoder encodes entries as CSV records. The columns time, level,
+// name, file, line, message and scope are taken from the Entry, all others
+// from its Fields. If Header is set, the column names are written before
+// the first entry.
+type CSVEncoder struct {
+	Columns    []string
+	TimeFormat string
+	Header     bool
+	Mutex      sync.Mutex
+	header     bool
+}
+
+// NewCSVEncoder returns a new CSVEncoder writing the columns, or
+// DefaultCSVColumns if none are given.
+func NewCSVEncoder(columns ...string) *CSVEncoder {
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+	return &CSVEncoder{
+		Columns:    columns,
+		TimeFormat: DefaultTimeFormat,
+		Header:     true,
+	}
+}
+
+func newCSVEncoder(options map[string]string) (Encoder, error) {
+	var columns []string
+	if v, ok := options["columns"]; ok {
+		columns = strings.Split(v, ",")
+	}
+	enc := NewCSVEncoder(columns...)
+	if v, ok := options["time_format"]; ok {
+		enc.TimeFormat = v
+	}
+	if v, ok := options["header"]; ok {
+		header, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("slogx: invalid header option '%s'", v)
+		}
+		enc.Header = header
+	}
+	return enc, nil
+}
+
+// Encode returns the Entry as a CSV record, preceded by the header for
+// the first Entry.
+func (enc *CSVEncoder) Encode(e Entry) ([]byte, error) {
+	record := make([]string, len(enc.Columns))
+	for i, column := range enc.Columns {
+		switch column {
+		case "time":
+			record[i] = e.Time.Format(enc.TimeFormat)
+		case "level":
+			record[i] = e.Level.String()
+		case "name":
+			record[i] = e.Name
+		case "file":
+			record[i] = e.File
+		case "line":
+			record[i] = strconv.Itoa(e.Line)
+		case "message":
+			record[i] = e.Message
+		case "scope":
+			record[i] = e.Scope
+		default:
+			if v, ok := e.Fields[column]; ok {
+				record[i] = fmt.Sprint(v)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	enc.Mutex.Lock()
+	if enc.Header && !enc.header {
+		w.Write(enc.Columns)
+	}
+	enc.header = true
+	enc.Mutex.Unlock()
+	w.Write(record)
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,188 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DatabaseHook is a Hook that inserts entries into a database table.
+// The insert statement uses $n placeholders as understood by Postgres
+// and SQLite. The Fields are inserted as a JSON object.
+type DatabaseHook struct {
+	DB         *sql.DB
+	Table      string
+	BatchSize  int
+	DeadLetter string
+	Mutex      sync.Mutex
+	stmt       *sql.Stmt
+	batch      []Entry
+	done       chan struct{}
+	once       sync.Once
+}
+
+// NewDatabaseHook returns a new DatabaseHook inserting into the table.
+// Entries are inserted when BatchSize is reached and every interval,
+// which must be positive.
+func NewDatabaseHook(db *sql.DB, table string, interval time.Duration) (*DatabaseHook, error) {
+	if !validTable(table) {
+		return nil, fmt.Errorf("slogx: invalid table name '%s'", table)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("slogx: invalid interval '%s'", interval)
+	}
+	stmt, err := db.Prepare(fmt.Sprintf(
+		"INSERT INTO %s (time, level, logger, file, line, message, fields) VALUES ($1, $2, $3, $4, $5, $6, $7)", table))
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	h := &DatabaseHook{
+		DB:        db,
+		Table:     table,
+		BatchSize: 100,
+		stmt:      stmt,
+		done:      make(chan struct{}),
+	}
+	go h.run(interval)
+	return h, nil
+}
+
+// CreateDatabaseTable creates the table if it does not exist.
+func CreateDatabaseTable(db *sql.DB, table string) error {
+	if !validTable(table) {
+		return fmt.Errorf("slogx: invalid table name '%s'", table)
+	}
+	_, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	time TIMESTAMP NOT NULL,
+	level TEXT NOT NULL,
+	logger TEXT NOT NULL,
+	file TEXT NOT NULL,
+	line INTEGER NOT NULL,
+	message TEXT NOT NULL,
+	fields JSONB NOT NULL
+)`, table))
+	return err
+}
+
+// validTable reports whether the table name is an identifier, optionally
+// qualified with a schema, so it can be used in statements unquoted.
+func validTable(table string) bool {
+	if table == "" || table[0] == '.' || table[len(table)-1] == '.' {
+		return false
+	}
+	dots := 0
+	for i, r := range table {
+		switch {
+		case r == '.':
+			dots++
+			if table[i-1] == '.' {
+				return false
+			}
+		case r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9':
+			if i == 0 || table[i-1] == '.' {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return dots <= 1
+}
+
+func (h *DatabaseHook) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := h.Flush(); err != nil {
+				diagnose(ERROR, Fields{"error": err, "hook": "database"}, "flush failed")
+			}
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// Fire adds the Entry to the current batch.
+func (h *DatabaseHook) Fire(e Entry) error {
+	h.Mutex.Lock()
+	h.batch = append(h.batch, e)
+	full := len(h.batch) >= h.BatchSize
+	h.Mutex.Unlock()
+	if full {
+		return h.Flush()
+	}
+	return nil
+}
+
+// Flush inserts the current batch in a single transaction. If inserting
+// fails and DeadLetter is set, the batch is appended to the dead-letter file.
+func (h *DatabaseHook) Flush() error {
+	h.Mutex.Lock()
+	batch := h.batch
+	h.batch = nil
+	h.Mutex.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	err := h.insert(batch)
+	if err != nil && h.DeadLetter != "" {
+		if err := WriteDeadLetter(h.DeadLetter, batch); err != nil {
+			return err
+		}
+		diagnose(WARNING, Fields{"error": err, "hook": "database", "entries": len(batch), "path": h.DeadLetter},
+			"batch written to dead-letter file")
+	}
+	return err
+}
+
+func (h *DatabaseHook) insert(batch []Entry) error {
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return err
+	}
+	stmt := tx.Stmt(h.stmt)
+	for _, e := range batch {
+		_, err := stmt.Exec(e.Time.UTC(), e.Level.String(), e.Name, e.File, e.Line, e.Message, encodeFields(e.Fields))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Close inserts the current batch and stops inserting entries periodically.
+// Closing it again does nothing.
+func (h *DatabaseHook) Close() error {
+	var err error
+	h.once.Do(func() {
+		close(h.done)
+		err = h.Flush()
+		h.stmt.Close()
+	})
+	return err
+}
+
+// encodeFields returns the Fields as a JSON object. Errors are encoded as
+// their message and values that cannot be encoded as their default format.
+func encodeFields(fields Fields) string {
+	m := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		} else if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprint(v)
+		}
+		m[k] = v
+	}
+	b, _ := json.Marshal(m)
+	return string(b)
+}
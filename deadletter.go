@@ -0,0 +1,166 @@
+package slogx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WriteDeadLetter appends the entries to the dead-letter file at path,
+// one JSON object per line.
+func WriteDeadLetter(path string, entries []Entry) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	enc := NewJSONEncoder()
+	w := bufio.NewWriter(f)
+	for _, e := range entries {
+		b, err := enc.Encode(e)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(b)
+		w.WriteString("\n")
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Replay fires every entry in the dead-letter file at path into the Hook
+// and removes the file. If the Hook fails, the file is replaced with the
+// remaining entries and the error is returned. Lines that cannot be
+// decoded are moved to a file with the suffix .corrupt. Hooks with a Flush
+// method are flushed afterwards; a batching Hook should set its own
+// DeadLetter to keep entries it fails to flush.
+func Replay(path string, hook Hook) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var rest []string
+	var fireErr error
+	lines := splitLines(data)
+	for i, line := range lines {
+		e, err := decodeJSONEntry([]byte(line), time.RFC3339Nano)
+		if err != nil {
+			quarantine(path, line, err)
+			continue
+		}
+		if err := hook.Fire(e); err != nil {
+			rest, fireErr = lines[i:], err
+			break
+		}
+	}
+	// Keep entries the Hook appended to the file in the meantime.
+	if current, err := os.ReadFile(path); err == nil && len(current) > len(data) {
+		rest = append(rest, splitLines(current[len(data):])...)
+	}
+	if err := replaceLines(path, rest); err != nil {
+		return err
+	}
+	if fireErr != nil {
+		return fireErr
+	}
+	if f, ok := hook.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// splitLines returns the non-empty lines of data.
+func splitLines(data []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// replaceLines atomically replaces the file at path with the lines, or
+// removes it if there are none.
+func replaceLines(path string, lines []string) error {
+	if len(lines) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil {
+		f.Chmod(info.Mode())
+	}
+	if _, err := f.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// quarantine appends a line that cannot be decoded to the file at path
+// with the suffix .corrupt.
+func quarantine(path string, line string, err error) {
+	diagnose(WARNING, Fields{"error": err, "file": path}, "corrupt line quarantined")
+	f, err := os.OpenFile(path+".corrupt", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	f.WriteString(line + "\n")
+	f.Close()
+}
+
+// decodeJSONEntry decodes an Entry encoded by a JSONEncoder with the time
+// layout.
+func decodeJSONEntry(line []byte, layout string) (Entry, error) {
+	var m map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(line))
+	d.UseNumber()
+	if err := d.Decode(&m); err != nil {
+		return Entry{}, err
+	}
+	var e Entry
+	str := func(key string) string {
+		s, _ := m[key].(string)
+		return s
+	}
+	if t, err := time.Parse(layout, str("time")); err == nil {
+		e.Time = t
+	}
+	e.Level = ParseLevel(str("level"))
+	e.Name = str("name")
+	e.File = str("file")
+	if n, ok := m["line"].(json.Number); ok {
+		line, _ := n.Int64()
+		e.Line = int(line)
+	}
+	e.Message = str("message")
+	e.Scope = str("scope")
+	for k, v := range m {
+		if jsonKeys[k] {
+			continue
+		}
+		if e.Fields == nil {
+			e.Fields = Fields{}
+		}
+		e.Fields[strings.TrimPrefix(k, "fields.")] = v
+	}
+	return e, nil
+}
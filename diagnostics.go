@@ -0,0 +1,53 @@
+package slogx
+
+import (
+	"os"
+	"time"
+)
+
+// diagnostics is not registered, so it is configured separately from the
+// application loggers.
+var diagnostics = func() *Logger {
+	l := newLogger("slogx")
+	l.SetLevel(WARNING)
+	l.SetOutput(os.Stderr)
+	return l
+}()
+
+// Diagnostics returns the Logger that slogx logs its own events to, such
+// as failed writes and hooks, sink reconnects, dropped entries and
+// dead-letter writes. It writes to stderr at Level WARNING by default.
+func Diagnostics() *Logger {
+	return diagnostics
+}
+
+// diagnose logs an event of the Logger to the diagnostics Logger.
+func (l *Logger) diagnose(level Level, fields Fields, msg string) {
+	if l == diagnostics {
+		return
+	}
+	if fields == nil {
+		fields = Fields{}
+	}
+	if l != nil {
+		fields["logger"] = l.GetName()
+	}
+	diagnose(level, fields, msg)
+}
+
+// diagnose logs an event to the diagnostics Logger.
+func diagnose(level Level, fields Fields, msg string) {
+	if !diagnostics.enabled(level) {
+		return
+	}
+	site := caller(2)
+	diagnostics.output(Entry{
+		Time:    time.Now(),
+		Level:   level,
+		File:    site.file,
+		Line:    site.line,
+		Name:    diagnostics.GetName(),
+		Message: msg,
+		Fields:  fields,
+	})
+}
@@ -0,0 +1,128 @@
+package slogx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// diffMissing marks a side of a change where the value does not exist.
+const diffMissing = dumpMarker("<none>")
+
+// change is a changed value at a path of a Diff.
+type change struct {
+	path          string
+	before, after interface{}
+}
+
+// Diff logs the message at the specified Level with the field-level
+// differences between two values, expanded like by Dump, e.g. when a
+// configuration is reloaded. Every changed value is added as a field named
+// by its path, like Server.Port or Hosts[2]. With a JSONEncoder the field
+// is an object of the old and new value, without the missing one of an
+// added or removed value, otherwise it is formatted like
+// "8080 -> 9090".
+func (l *Logger) Diff(level Level, msg string, before, after interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	a := dumpValue(reflect.ValueOf(before), 0, make(map[uintptr]bool))
+	b := dumpValue(reflect.ValueOf(after), 0, make(map[uintptr]bool))
+	changes := diffTrees(nil, "", a, b)
+	_, structured := l.config().encoder.(*JSONEncoder)
+	fields := make(Fields, len(changes))
+	for _, c := range changes {
+		path := c.path
+		if path == "" {
+			path = "value"
+		}
+		if !structured {
+			fields[path] = diffString(c.before) + " -> " + diffString(c.after)
+			continue
+		}
+		values := make(map[string]interface{}, 2)
+		if c.before != diffMissing {
+			values["old"] = c.before
+		}
+		if c.after != diffMissing {
+			values["new"] = c.after
+		}
+		fields[path] = values
+	}
+	l.log(level, fields, msg)
+}
+
+// diffTrees appends the changes between two dumped values at the path.
+func diffTrees(changes []change, path string, a, b interface{}) []change {
+	switch a := a.(type) {
+	case *dumpObject:
+		if b, ok := b.(*dumpObject); ok {
+			for i, k := range a.keys {
+				if j := indexOf(b.keys, k); j >= 0 {
+					changes = diffTrees(changes, joinPath(path, k), a.values[i], b.values[j])
+				} else {
+					changes = append(changes, change{joinPath(path, k), a.values[i], diffMissing})
+				}
+			}
+			for j, k := range b.keys {
+				if indexOf(a.keys, k) < 0 {
+					changes = append(changes, change{joinPath(path, k), diffMissing, b.values[j]})
+				}
+			}
+			return changes
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			for i := 0; i < len(a) || i < len(b); i++ {
+				p := path + "[" + strconv.Itoa(i) + "]"
+				switch {
+				case i >= len(a):
+					changes = append(changes, change{p, diffMissing, b[i]})
+				case i >= len(b):
+					changes = append(changes, change{p, a[i], diffMissing})
+				default:
+					changes = diffTrees(changes, p, a[i], b[i])
+				}
+			}
+			return changes
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		changes = append(changes, change{path, a, b})
+	}
+	return changes
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func indexOf(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// diffString returns a dumped value formatted on a single line.
+func diffString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case dumpMarker:
+		return string(v)
+	case nil:
+		return "nil"
+	case *dumpObject, []interface{}:
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprint(v)
+}
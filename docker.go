@@ -0,0 +1,85 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// DockerRecord is a single record of Docker's json-file log format.
+type DockerRecord struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// DockerWriter is an io.Writer that wraps each line in a DockerRecord.
+type DockerWriter struct {
+	Output io.Writer
+	Stream string
+	Mutex  sync.Mutex
+	buf    []byte
+}
+
+// NewDockerWriter returns a new DockerWriter writing records for the stream to w.
+func NewDockerWriter(w io.Writer, stream string) *DockerWriter {
+	return &DockerWriter{
+		Output: w,
+		Stream: stream,
+	}
+}
+
+// Write writes a record for every complete line in p.
+func (w *DockerWriter) Write(p []byte) (int, error) {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		rec, err := json.Marshal(DockerRecord{
+			Log:    string(w.buf[:i+1]),
+			Stream: w.Stream,
+			Time:   time.Now().UTC().Format(time.RFC3339Nano),
+		})
+		if err != nil {
+			return 0, err
+		}
+		if _, err := w.Output.Write(append(rec, '\n')); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// DockerReader is an io.Reader that reads the log text of DockerRecords.
+type DockerReader struct {
+	dec *json.Decoder
+	buf []byte
+}
+
+// NewDockerReader returns a new DockerReader reading records from r.
+func NewDockerReader(r io.Reader) *DockerReader {
+	return &DockerReader{
+		dec: json.NewDecoder(r),
+	}
+}
+
+// Read reads the log text of the next records into p.
+func (r *DockerReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		var rec DockerRecord
+		if err := r.dec.Decode(&rec); err != nil {
+			return 0, err
+		}
+		r.buf = []byte(rec.Log)
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
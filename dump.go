@@ -0,0 +1,202 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	maxDumpDepth     = 6
+	maxHexdumpLength = 4096
+)
+
+// dumpMarker replaces a value that is not expanded.
+type dumpMarker string
+
+// dumpObject is a struct or map of a dumped value, keeping its key order.
+type dumpObject struct {
+	typ    string
+	keys   []string
+	values []interface{}
+}
+
+func (o *dumpObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, _ := json.Marshal(k)
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// Dump logs a labeled value at the specified Level. Structs, maps, slices
+// and pointers are expanded up to a fixed depth, and cycles are detected.
+// With a JSONEncoder the value is added as a nested field, otherwise it is
+// logged as an indented block.
+func (l *Logger) Dump(level Level, label string, value interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	tree := dumpValue(reflect.ValueOf(value), 0, make(map[uintptr]bool))
+	if _, ok := l.config().encoder.(*JSONEncoder); ok {
+		l.log(level, Fields{label: tree}, label)
+		return
+	}
+	var b strings.Builder
+	b.WriteString(label)
+	b.WriteString(": ")
+	writeDump(&b, tree, 0)
+	l.log(level, nil, b.String())
+}
+
+// Hexdump logs a labeled canonical hex and ASCII dump of the data at the
+// specified Level. Data beyond 4096 bytes is truncated.
+func (l *Logger) Hexdump(level Level, label string, data []byte) {
+	if !l.enabled(level) {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d bytes\n", label, len(data))
+	if len(data) > maxHexdumpLength {
+		b.WriteString(hex.Dump(data[:maxHexdumpLength]))
+		fmt.Fprintf(&b, "... %d bytes truncated", len(data)-maxHexdumpLength)
+	} else {
+		b.WriteString(strings.TrimSuffix(hex.Dump(data), "\n"))
+	}
+	l.log(level, nil, b.String())
+}
+
+func dumpValue(v reflect.Value, depth int, seen map[uintptr]bool) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		switch i := v.Interface().(type) {
+		case error:
+			if v.Kind() != reflect.Ptr || !v.IsNil() {
+				return i.Error()
+			}
+		case fmt.Stringer:
+			if v.Kind() != reflect.Ptr || !v.IsNil() {
+				return i.String()
+			}
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			if seen[v.Pointer()] {
+				return dumpMarker("<cycle>")
+			}
+			seen[v.Pointer()] = true
+			defer delete(seen, v.Pointer())
+		}
+		return dumpValue(v.Elem(), depth, seen)
+	case reflect.Struct:
+		if depth >= maxDumpDepth {
+			return dumpMarker("<max depth>")
+		}
+		o := &dumpObject{typ: v.Type().String()}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			o.keys = append(o.keys, f.Name)
+			o.values = append(o.values, dumpValue(v.Field(i), depth+1, seen))
+		}
+		return o
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if depth >= maxDumpDepth {
+			return dumpMarker("<max depth>")
+		}
+		o := &dumpObject{typ: v.Type().String()}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			o.keys = append(o.keys, fmt.Sprint(k))
+			o.values = append(o.values, dumpValue(v.MapIndex(k), depth+1, seen))
+		}
+		return o
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%x", v.Bytes())
+		}
+		if depth >= maxDumpDepth {
+			return dumpMarker("<max depth>")
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = dumpValue(v.Index(i), depth+1, seen)
+		}
+		return items
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.Type().String()
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
+
+func writeDump(b *strings.Builder, tree interface{}, indent int) {
+	pad := strings.Repeat("  ", indent+1)
+	switch t := tree.(type) {
+	case *dumpObject:
+		b.WriteString(t.typ)
+		b.WriteString("{\n")
+		for i, k := range t.keys {
+			b.WriteString(pad)
+			b.WriteString(k)
+			b.WriteString(": ")
+			writeDump(b, t.values[i], indent+1)
+			b.WriteString("\n")
+		}
+		b.WriteString(pad[2:])
+		b.WriteString("}")
+	case []interface{}:
+		b.WriteString("[\n")
+		for _, item := range t {
+			b.WriteString(pad)
+			writeDump(b, item, indent+1)
+			b.WriteString("\n")
+		}
+		b.WriteString(pad[2:])
+		b.WriteString("]")
+	case string:
+		fmt.Fprintf(b, "%q", t)
+	case dumpMarker:
+		b.WriteString(string(t))
+	case nil:
+		b.WriteString("nil")
+	default:
+		fmt.Fprint(b, t)
+	}
+}
@@ -0,0 +1,208 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Encoder encodes an Entry into a single line of output.
+type Encoder interface {
+	Encode(e Entry) ([]byte, error)
+}
+
+// EncoderFactory returns a new Encoder configured by options.
+type EncoderFactory func(options map[string]string) (Encoder, error)
+
+var (
+	encoders      = make(map[string]EncoderFactory)
+	encodersMutex sync.Mutex
+)
+
+func init() {
+	RegisterEncoder("text", newTextEncoder)
+	RegisterEncoder("json", newJSONEncoder)
+	RegisterEncoder("w3c", newW3CEncoder)
+	RegisterEncoder("common", newCommonLogEncoder)
+	RegisterEncoder("combined", newCombinedLogEncoder)
+	RegisterEncoder("cef", newCEFEncoder)
+	RegisterEncoder("leef", newLEEFEncoder)
+	RegisterEncoder("csv", newCSVEncoder)
+	RegisterEncoder("msgpack", newMsgpackEncoder)
+	RegisterEncoder("cbor", newCBOREncoder)
+	RegisterEncoder("protobuf", newProtobufEncoder)
+}
+
+// RegisterEncoder registers an EncoderFactory by name, replacing any
+// factory already registered with the name.
+func RegisterEncoder(name string, factory EncoderFactory) {
+	encodersMutex.Lock()
+	defer encodersMutex.Unlock()
+	encoders[name] = factory
+}
+
+// NewEncoder returns a new Encoder from the factory registered by name.
+func NewEncoder(name string, options map[string]string) (Encoder, error) {
+	encodersMutex.Lock()
+	factory, ok := encoders[name]
+	encodersMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("slogx: unknown encoder '%s'", name)
+	}
+	return factory(options)
+}
+
+// TextEncoder encodes entries using a Format and TimeFormat like a Logger.
+type TextEncoder struct {
+	Format     string
+	TimeFormat string
+	Precision  time.Duration
+}
+
+// NewTextEncoder returns a new TextEncoder for a format using the same
+// verbs as SetFormat.
+func NewTextEncoder(format string, layout string) (*TextEncoder, error) {
+	parsed, err := parseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	return &TextEncoder{
+		Format:     parsed,
+		TimeFormat: layout,
+	}, nil
+}
+
+func newTextEncoder(options map[string]string) (Encoder, error) {
+	format, layout := DefaultFormat, DefaultTimeFormat
+	if v, ok := options["format"]; ok {
+		format = v
+	}
+	if v, ok := options["time_format"]; ok {
+		layout = v
+	}
+	enc, err := NewTextEncoder(format, layout)
+	if err != nil {
+		return nil, err
+	}
+	if enc.Precision, err = precisionOption(options); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+// Encode returns the Entry formatted as text.
+func (enc *TextEncoder) Encode(e Entry) ([]byte, error) {
+	return []byte(formatEntry(enc.Format, withPrecision(enc.TimeFormat, enc.Precision), e)), nil
+}
+
+// JSONEncoder encodes entries as JSON objects. Fields are added at the top
+// level; fields named like a built-in key are prefixed with "fields.". With
+// OTel, the file, line and fields are named by the OTelFields, and the type
+// of an error field is added as exception.type.
+type JSONEncoder struct {
+	TimeFormat string
+	Precision  time.Duration
+	OTel       bool
+}
+
+// NewJSONEncoder returns a new JSONEncoder with RFC 3339 timestamps.
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{
+		TimeFormat: time.RFC3339Nano,
+	}
+}
+
+func newJSONEncoder(options map[string]string) (Encoder, error) {
+	enc := NewJSONEncoder()
+	if v, ok := options["time_format"]; ok {
+		enc.TimeFormat = v
+	}
+	if v, ok := options["otel"]; ok {
+		otel, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("slogx: invalid otel option '%s'", v)
+		}
+		enc.OTel = otel
+	}
+	var err error
+	if enc.Precision, err = precisionOption(options); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+var jsonKeys = map[string]bool{
+	"time":    true,
+	"level":   true,
+	"name":    true,
+	"file":    true,
+	"line":    true,
+	"message": true,
+	"scope":   true,
+}
+
+// Encode returns the Entry as a JSON object.
+func (enc *JSONEncoder) Encode(e Entry) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	add := func(key string, value interface{}) error {
+		v, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if b.Len() > 1 {
+			b.WriteString(",")
+		}
+		k, _ := json.Marshal(key)
+		b.Write(k)
+		b.WriteString(":")
+		b.Write(v)
+		return nil
+	}
+	add("time", e.Time.Format(withPrecision(enc.TimeFormat, enc.Precision)))
+	add("level", e.Level.String())
+	add("name", e.Name)
+	if enc.OTel {
+		add(otelKey("file"), e.File)
+		add(otelKey("line"), e.Line)
+	} else {
+		add("file", e.File)
+		add("line", e.Line)
+	}
+	add("message", e.Message)
+	if e.Scope != "" {
+		add("scope", e.Scope)
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key := k
+		if jsonKeys[k] {
+			key = "fields." + k
+		} else if enc.OTel {
+			key = otelKey(k)
+		}
+		value := e.Fields[k]
+		if err, ok := value.(error); ok {
+			if enc.OTel && key == otelKey("error") {
+				add("exception.type", fmt.Sprintf("%T", err))
+			}
+			value = err.Error()
+		}
+		if err := add(key, value); err != nil {
+			if err := add(key, fmt.Sprint(value)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
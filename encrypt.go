@@ -0,0 +1,207 @@
+package slogx
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Record types of the encrypted format.
+const (
+	keyRecord  = 'K'
+	dataRecord = 'D'
+)
+
+// MaxRecordSize is the largest sealed record an EncryptWriter writes and
+// Decrypt accepts. Longer lengths are treated as corrupt.
+var MaxRecordSize = 16 << 20
+
+// EncryptWriter is an io.Writer that encrypts log data at rest, for
+// devices whose local logs may be obtained by untrusted parties. Every
+// write is sealed with AES-256-GCM as a separate record, so a truncated
+// file loses at most its last write. The random key of the writer is
+// wrapped with an RSA public key and written before its first record.
+// Decrypt reads the output with the private key.
+type EncryptWriter struct {
+	Output  io.Writer
+	Mutex   sync.Mutex
+	aead    cipher.AEAD
+	key     []byte
+	started bool
+	counter uint64
+}
+
+// NewEncryptWriter returns a new EncryptWriter writing to w with a
+// PEM-encoded RSA public key.
+func NewEncryptWriter(w io.Writer, publicKey []byte) (*EncryptWriter, error) {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return nil, fmt.Errorf("slogx: invalid public key")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	pub, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("slogx: public key is not an RSA key")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, secret, nil)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	return &EncryptWriter{
+		Output: w,
+		aead:   aead,
+		key:    wrapped,
+	}, nil
+}
+
+func newAEAD(secret []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	return aead, nil
+}
+
+// nonce returns the nonce of the record with the counter.
+func nonce(counter uint64) []byte {
+	n := make([]byte, 12)
+	binary.BigEndian.PutUint64(n[4:], counter)
+	return n
+}
+
+// Write encrypts p as a single record, preceded by the wrapped key until
+// a write succeeds. Records carry their counter, which advances even when
+// the write fails, so a nonce is never reused.
+func (w *EncryptWriter) Write(p []byte) (int, error) {
+	if len(p)+w.aead.Overhead() > MaxRecordSize {
+		return 0, fmt.Errorf("slogx: record exceeds %d bytes", MaxRecordSize)
+	}
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	var b []byte
+	if !w.started {
+		b = append(b, keyRecord, 0, 0)
+		binary.BigEndian.PutUint16(b[1:], uint16(len(w.key)))
+		b = append(b, w.key...)
+	}
+	counter := w.counter
+	w.counter++
+	start := len(b)
+	b = append(b, dataRecord, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(b[start+5:], counter)
+	b = w.aead.Seal(b, nonce(counter), p, nil)
+	binary.BigEndian.PutUint32(b[start+1:], uint32(len(b)-start-13))
+	if _, err := w.Output.Write(b); err != nil {
+		return 0, err
+	}
+	w.started = true
+	return len(p), nil
+}
+
+// Decrypt writes the decrypted output of EncryptWriters read from r to w
+// with a PEM-encoded RSA private key in PKCS #1 or PKCS #8 form. The output
+// of several writers, e.g. appending to the same file, is decrypted in
+// order. Decrypt stops at the first record that is truncated, corrupt or
+// fails to authenticate and returns an error; what was decrypted before it
+// has already been written to w.
+func Decrypt(w io.Writer, r io.Reader, privateKey []byte) error {
+	priv, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+	var aead cipher.AEAD
+	header := make([]byte, 13)
+	for {
+		if _, err := io.ReadFull(r, header[:1]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("slogx: %v", err)
+		}
+		switch header[0] {
+		case keyRecord:
+			if _, err := io.ReadFull(r, header[1:3]); err != nil {
+				return fmt.Errorf("slogx: truncated record")
+			}
+			wrapped := make([]byte, binary.BigEndian.Uint16(header[1:3]))
+			if _, err := io.ReadFull(r, wrapped); err != nil {
+				return fmt.Errorf("slogx: truncated record")
+			}
+			secret, err := rsa.DecryptOAEP(sha256.New(), nil, priv, wrapped, nil)
+			if err != nil {
+				return fmt.Errorf("slogx: %v", err)
+			}
+			if aead, err = newAEAD(secret); err != nil {
+				return err
+			}
+		case dataRecord:
+			if aead == nil {
+				return fmt.Errorf("slogx: data record before key record")
+			}
+			if _, err := io.ReadFull(r, header[1:13]); err != nil {
+				return fmt.Errorf("slogx: truncated record")
+			}
+			n := binary.BigEndian.Uint32(header[1:5])
+			if int64(n) > int64(MaxRecordSize) {
+				return fmt.Errorf("slogx: corrupt record")
+			}
+			sealed := make([]byte, n)
+			if _, err := io.ReadFull(r, sealed); err != nil {
+				return fmt.Errorf("slogx: truncated record")
+			}
+			counter := binary.BigEndian.Uint64(header[5:13])
+			p, err := aead.Open(sealed[:0], nonce(counter), sealed, nil)
+			if err != nil {
+				return fmt.Errorf("slogx: %v", err)
+			}
+			if _, err := w.Write(p); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("slogx: invalid record type '%c'", header[0])
+		}
+	}
+}
+
+func parsePrivateKey(privateKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, fmt.Errorf("slogx: invalid private key")
+	}
+	if priv, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return priv, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	priv, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("slogx: private key is not an RSA key")
+	}
+	return priv, nil
+}
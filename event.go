@@ -0,0 +1,134 @@
+package slogx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Event is a typed event, a struct whose exported fields are logged as
+// Fields, for analytics-grade events checked at compile time:
+//
+//	type UserLoggedIn struct {
+//		UserID string
+//		Method string
+//	}
+//
+//	func (UserLoggedIn) EventName() string { return "user_logged_in" }
+type Event interface {
+	EventName() string
+}
+
+// eventType is a registered Event.
+type eventType struct {
+	typ   reflect.Type
+	level Level
+}
+
+var (
+	events      = make(map[string]eventType)
+	eventsMutex sync.RWMutex
+	eventFields sync.Map
+)
+
+// RegisterEvent registers the type of the Event, a struct or a pointer to
+// a struct, with the Level it is logged at. Event names must be unique.
+func RegisterEvent(event Event, level Level) error {
+	typ := reflect.TypeOf(event)
+	if typ == nil {
+		return fmt.Errorf("slogx: invalid event")
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("slogx: event '%s' is not a struct", event.EventName())
+	}
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+	name := event.EventName()
+	if e, ok := events[name]; ok && e.typ != typ {
+		return fmt.Errorf("slogx: event '%s' is already registered by %s", name, e.typ)
+	}
+	events[name] = eventType{typ: typ, level: level}
+	return nil
+}
+
+// Event logs the Event with its name as the message and the field event,
+// at the Level it was registered with, or INFO if it is not registered.
+// The exported fields of the Event are added as Fields named by their
+// slogx tag, or their name in snake case, like user_id for UserID. Fields
+// tagged with "-" are skipped.
+func (l *Logger) Event(e Event) {
+	name := e.EventName()
+	level := INFO
+	eventsMutex.RLock()
+	if et, ok := events[name]; ok {
+		level = et.level
+	}
+	eventsMutex.RUnlock()
+	if !l.enabled(level) {
+		return
+	}
+
+	v := reflect.ValueOf(e)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	fields := Fields{"event": name}
+	if v.Kind() == reflect.Struct {
+		for _, f := range structFields(v.Type()) {
+			fields[f.name] = v.Field(f.index).Interface()
+		}
+	}
+	l.log(level, fields, name)
+}
+
+// eventField is a logged field of an Event struct.
+type eventField struct {
+	index int
+	name  string
+}
+
+// structFields returns the logged fields of a struct type.
+func structFields(typ reflect.Type) []eventField {
+	if fields, ok := eventFields.Load(typ); ok {
+		return fields.([]eventField)
+	}
+	var fields []eventField
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Tag.Get("slogx")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = snakeCase(f.Name)
+		}
+		fields = append(fields, eventField{i, name})
+	}
+	eventFields.Store(typ, fields)
+	return fields
+}
+
+// snakeCase returns the name in snake case, keeping acronyms together,
+// like http_status for HTTPStatus.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
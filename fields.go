@@ -0,0 +1,95 @@
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fields are key-value pairs attached to an Entry.
+type Fields map[string]interface{}
+
+type fieldsKey struct{}
+
+// ContextWithFields returns a copy of the context carrying the Fields
+// merged with any Fields already in the context.
+func ContextWithFields(ctx context.Context, fields Fields) context.Context {
+	merged := make(Fields)
+	for k, v := range contextFields(ctx) {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the Fields carried by the context, including
+// the Fields of its MDC.
+func FieldsFromContext(ctx context.Context) Fields {
+	fields := contextFields(ctx)
+	m := MDCFromContext(ctx)
+	if m == nil {
+		return fields
+	}
+	merged := m.Fields()
+	for k, v := range fields {
+		if _, ok := merged[k]; !ok {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func contextFields(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(Fields)
+	return fields
+}
+
+// String returns the Fields as space-prefixed key=value pairs sorted by key.
+// Values containing spaces, quotes or equal signs are quoted.
+func (f Fields) String() string {
+	if len(f) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, k := range f.sortedKeys() {
+		v := fmt.Sprint(f[k])
+		if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+			v = strconv.Quote(v)
+		}
+		b.WriteString(" ")
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(v)
+	}
+	return b.String()
+}
+
+func (f Fields) sortedKeys() []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addField adds the field to a copy of the Fields of the Entry unless it
+// has the field.
+func addField(e *Entry, key string, value interface{}) {
+	if _, ok := e.Fields[key]; ok {
+		return
+	}
+	fields := make(Fields, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	e.Fields = fields
+}
@@ -0,0 +1,81 @@
+package slogx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type flusher interface {
+	Flush() error
+}
+
+type syncer interface {
+	Sync() error
+}
+
+// Flush flushes the outputs and Hooks of the logger that buffer writes,
+// i.e. implement Flush() error, and syncs outputs that implement
+// Sync() error, such as files. Buffered writes are lost if a wasm instance
+// is suspended or the process is killed before they are flushed.
+func (l *Logger) Flush() error {
+	c := l.config()
+	errs := c.flushOutputs()
+	for _, hook := range c.hooks {
+		if f, ok := hook.(flusher); ok {
+			if err := f.Flush(); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("slogx: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Flush flushes all registered and tenant loggers.
+func Flush() error {
+	var errs []string
+	for _, l := range allLoggers() {
+		if err := l.Flush(); err != nil {
+			errs = append(errs, strings.TrimPrefix(err.Error(), "slogx: "))
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("slogx: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// flushOutputs flushes the output and sinks and returns the errors.
+func (c *config) flushOutputs() []string {
+	writers := []io.Writer{c.output}
+	for _, s := range c.sinks {
+		writers = append(writers, s.output)
+	}
+	var errs []string
+	for _, w := range writers {
+		if err := flushWriter(w); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// flushWriter flushes or syncs w. Syncing stdout and stderr fails if they
+// are terminals or pipes, which is ignored.
+func flushWriter(w io.Writer) error {
+	switch w := w.(type) {
+	case flusher:
+		return w.Flush()
+	case syncer:
+		err := w.Sync()
+		if w == os.Stdout || w == os.Stderr {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package slogx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is a compiled format that renders entries like a Logger, for use
+// by tools and custom outputs.
+type Format struct {
+	spec   string
+	format string
+}
+
+// CompileFormat returns the Format of a spec using the same verbs as
+// SetFormat.
+func CompileFormat(spec string) (Format, error) {
+	format, err := parseFormat(spec)
+	if err != nil {
+		return Format{}, err
+	}
+	return Format{spec: spec, format: format}, nil
+}
+
+// String returns the spec of the Format.
+func (f Format) String() string {
+	return f.spec
+}
+
+// Render returns the Entry rendered with the Format and the time layout.
+func (f Format) Render(e Entry, layout string) string {
+	return formatEntry(f.format, layout, e)
+}
+
+// formatToken is a literal or a verb of a format.
+type formatToken struct {
+	literal string
+	verb    string
+}
+
+// scanFormat splits a format into literals and verbs. A literal "${" is
+// written as "$${". All invalid verbs are reported with their column and
+// the closest valid verb. A format without verbs is a constant prefix of
+// the message.
+func scanFormat(format string) ([]formatToken, error) {
+	var tokens []formatToken
+	var errs []string
+	var literal strings.Builder
+	verbs := 0
+	for i := 0; i < len(format); {
+		if strings.HasPrefix(format[i:], "$${") {
+			literal.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(format[i:], "${") {
+			literal.WriteByte(format[i])
+			i++
+			continue
+		}
+		end := i + 2
+		for end < len(format) && isVerbChar(format[end]) {
+			end++
+		}
+		if end == len(format) || format[end] != '}' {
+			errs = append(errs, fmt.Sprintf("unterminated verb '%s' at column %d", format[i:end], i+1))
+			i = end
+			continue
+		}
+		verb := format[i : end+1]
+		if _, ok := formatPlaceholders[verb]; !ok {
+			msg := fmt.Sprintf("invalid verb '%s' at column %d", verb, i+1)
+			if s := suggestVerb(verb); s != "" {
+				msg += fmt.Sprintf(", did you mean '%s'?", s)
+			}
+			errs = append(errs, msg)
+		}
+		if literal.Len() > 0 {
+			tokens = append(tokens, formatToken{literal: literal.String()})
+			literal.Reset()
+		}
+		tokens = append(tokens, formatToken{verb: verb})
+		verbs++
+		i = end + 1
+	}
+	if literal.Len() > 0 {
+		tokens = append(tokens, formatToken{literal: literal.String()})
+	}
+
+	switch {
+	case len(errs) == 1:
+		return nil, fmt.Errorf("slogx: %s", errs[0])
+	case len(errs) > 1:
+		return nil, fmt.Errorf("slogx: invalid format '%s': %s", format, strings.Join(errs, "; "))
+	case verbs == 0:
+		tokens = append(tokens, formatToken{verb: "${message}"})
+	}
+	return tokens, nil
+}
+
+func isVerbChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// suggestVerb returns the valid verb closest to an invalid one, or an empty
+// string if none is close. Abbreviations such as ${lvl} or ${msg} match the
+// verb they abbreviate.
+func suggestVerb(verb string) string {
+	name := strings.ToLower(verb[2 : len(verb)-1])
+	best, bestDistance := "", 3
+	for v := range formatPlaceholders {
+		candidate := v[2 : len(v)-1]
+		d := editDistance(name, candidate)
+		if isAbbreviation(name, candidate) {
+			d = 1
+		}
+		if d < bestDistance || d == bestDistance && v < best {
+			best, bestDistance = v, d
+		}
+	}
+	return best
+}
+
+// isAbbreviation reports whether s starts with the first letter of name and
+// its letters appear in name in order.
+func isAbbreviation(s string, name string) bool {
+	if s == "" || s[0] != name[0] {
+		return false
+	}
+	i := 0
+	for j := 0; i < len(s) && j < len(name); j++ {
+		if s[i] == name[j] {
+			i++
+		}
+	}
+	return i == len(s)
+}
+
+// editDistance returns the Levenshtein distance of a and b.
+func editDistance(a string, b string) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			next := min3(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], next
+		}
+	}
+	return row[len(b)]
+}
+
+func min3(a int, b int, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
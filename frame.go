@@ -0,0 +1,142 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"sync"
+)
+
+// frameMagic starts every frame, so a FrameReader can find the next frame
+// after a corrupt one.
+var frameMagic = []byte{'s', 'l', 'x', 0xf5}
+
+// MaxFrameSize is the largest record a FrameReader accepts. Longer lengths
+// are treated as corrupt.
+var MaxFrameSize = 16 << 20
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// FrameWriter is an io.Writer that frames every write as a record with a
+// magic number, a length prefix and a CRC-32C checksum, so records
+// partially written before a crash are detected and skipped by a
+// FrameReader.
+type FrameWriter struct {
+	Output io.Writer
+	Mutex  sync.Mutex
+}
+
+// NewFrameWriter returns a new FrameWriter writing to w.
+func NewFrameWriter(w io.Writer) *FrameWriter {
+	return &FrameWriter{
+		Output: w,
+	}
+}
+
+// Write writes p as a single frame.
+func (w *FrameWriter) Write(p []byte) (int, error) {
+	b := make([]byte, len(p)+12)
+	copy(b, frameMagic)
+	binary.BigEndian.PutUint32(b[4:], uint32(len(p)))
+	copy(b[8:], p)
+	binary.BigEndian.PutUint32(b[len(p)+8:], crc32.Checksum(b[4:len(p)+8], crcTable))
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	if _, err := w.Output.Write(b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the Output if it is an io.Closer.
+func (w *FrameWriter) Close() error {
+	if c, ok := w.Output.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// FrameReader reads the records written by a FrameWriter, skipping corrupt
+// and partially written frames.
+type FrameReader struct {
+	Corrupt int
+	r       io.Reader
+	buf     []byte
+	eof     bool
+}
+
+// NewFrameReader returns a new FrameReader reading from r.
+func NewFrameReader(r io.Reader) *FrameReader {
+	return &FrameReader{
+		r: r,
+	}
+}
+
+// Next returns the next intact record, or io.EOF at the end of the input.
+// Corrupt frames are skipped and counted by Corrupt.
+func (r *FrameReader) Next() ([]byte, error) {
+	corrupt := false
+	for {
+		if err := r.fill(8); err != nil {
+			if len(r.buf) > 0 {
+				r.Corrupt++
+				r.buf = nil
+			} else if corrupt {
+				r.Corrupt++
+			}
+			return nil, err
+		}
+		if !bytes.Equal(r.buf[:4], frameMagic) {
+			r.skip(&corrupt)
+			continue
+		}
+		n := int(binary.BigEndian.Uint32(r.buf[4:8]))
+		if n > MaxFrameSize {
+			r.skip(&corrupt)
+			continue
+		}
+		if err := r.fill(n + 12); err != nil {
+			if err == io.EOF {
+				r.skip(&corrupt)
+				continue
+			}
+			return nil, err
+		}
+		if binary.BigEndian.Uint32(r.buf[n+8:]) != crc32.Checksum(r.buf[4:n+8], crcTable) {
+			r.skip(&corrupt)
+			continue
+		}
+		if corrupt {
+			r.Corrupt++
+		}
+		record := append([]byte(nil), r.buf[8:n+8]...)
+		r.buf = r.buf[n+12:]
+		return record, nil
+	}
+}
+
+// skip drops the first byte of the buffer to search for the next frame.
+func (r *FrameReader) skip(corrupt *bool) {
+	*corrupt = true
+	r.buf = r.buf[1:]
+}
+
+// fill reads until the buffer holds n bytes. It returns io.EOF if the
+// input ends first.
+func (r *FrameReader) fill(n int) error {
+	for len(r.buf) < n {
+		if r.eof {
+			return io.EOF
+		}
+		chunk := make([]byte, 32<<10)
+		m, err := r.r.Read(chunk)
+		r.buf = append(r.buf, chunk[:m]...)
+		if err == io.EOF {
+			r.eof = true
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// httpConfig is the configuration of the HTTP handlers of a Logger.
+type httpConfig struct {
+	slowRequest time.Duration
+	slowHeaders []string
+	sampled     *accessSampler
+	suppressed  []string
+	route       func(r *http.Request) string
+}
+
+// accessSampler counts the successful requests of AccessLog.
+type accessSampler struct {
+	every uint64
+	count uint64
+}
+
+// RequestIDHeader is the header used to read and set request IDs.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns a handler adding the request ID of each request to the
+// Fields of its context as request_id. The ID is read from the
+// X-Request-ID header or generated, and set on the response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		ctx := ContextWithFields(r.Context(), Fields{"request_id": id})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Recover returns a handler logging panics of next at ERROR Level with
+// their stack trace and the Fields of the request context. Unless the
+// Logger re-panics, a 500 response is written.
+func (l *Logger) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			fields := Fields{}
+			for k, v := range FieldsFromContext(r.Context()) {
+				fields[k] = v
+			}
+			fields["method"] = r.Method
+			fields["path"] = r.URL.Path
+			c := l.config()
+			c.routePattern(r, fields)
+			l.logPanic(v, fields)
+			if c.repanic {
+				panic(v)
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LoggersHandler returns a handler serving the Snapshot as JSON, e.g. for
+// a /debug/loggers status page.
+func LoggersHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Snapshot())
+	})
+}
@@ -0,0 +1,7 @@
+//go:build tinygo
+// +build tinygo
+
+package slogx
+
+// httpConfig is empty, as the HTTP handlers are not available under TinyGo.
+type httpConfig struct{}
@@ -0,0 +1,53 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var klogHeaderRegexp = regexp.MustCompile(`^([IWEF])\d{4} \d{2}:\d{2}:\d{2}\.\d{6}\s+\d+ ([^:\]]+):(\d+)\] `)
+
+var klogSeverityToLevel = map[string]Level{
+	"I": INFO,
+	"W": WARNING,
+	"E": ERROR,
+	"F": FATAL,
+}
+
+// KlogWriter is an io.Writer that logs klog output through a Logger.
+type KlogWriter struct {
+	Logger *Logger
+}
+
+// NewKlogWriter returns a new KlogWriter logging through the Logger.
+func NewKlogWriter(logger *Logger) *KlogWriter {
+	return &KlogWriter{
+		Logger: logger,
+	}
+}
+
+// Write logs a single klog entry. The Level, file and line are taken
+// from the klog header. Entries with a FATAL header do not exit.
+func (w *KlogWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	e := Entry{
+		Time:  w.Logger.config().now(),
+		Level: INFO,
+		Name:  w.Logger.GetName(),
+	}
+	if m := klogHeaderRegexp.FindStringSubmatch(msg); m != nil {
+		e.Level = klogSeverityToLevel[m[1]]
+		e.File = m[2]
+		e.Line, _ = strconv.Atoi(m[3])
+		msg = msg[len(m[0]):]
+	}
+	e.Message = msg
+	if w.Logger.enabled(e.Level) {
+		w.Logger.output(e)
+	}
+	return len(p), nil
+}
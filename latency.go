@@ -0,0 +1,169 @@
+package slogx
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBuckets is the number of histogram buckets. Bucket i counts
+// latencies below 2^i microseconds, the last one all others.
+const latencyBuckets = 22
+
+// latencyHistogram is an exponential histogram of latencies.
+type latencyHistogram struct {
+	count   uint64
+	sum     int64
+	buckets [latencyBuckets]uint64
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	i := 0
+	for us := d.Microseconds(); us > 0 && i < latencyBuckets-1; us >>= 1 {
+		i++
+	}
+	atomic.AddUint64(&h.buckets[i], 1)
+	atomic.AddUint64(&h.count, 1)
+	atomic.AddInt64(&h.sum, int64(d))
+}
+
+// writeJSON writes the histogram as a JSON object with the count, the sum
+// in nanoseconds and the counts of the buckets by upper bound.
+func (h *latencyHistogram) writeJSON(b *bytes.Buffer) {
+	fmt.Fprintf(b, `{"count":%d,"sum_ns":%d,"buckets":{`, atomic.LoadUint64(&h.count), atomic.LoadInt64(&h.sum))
+	for i := range h.buckets {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		bound := "+Inf"
+		if i < latencyBuckets-1 {
+			bound = (time.Duration(1<<i) * time.Microsecond).String()
+		}
+		fmt.Fprintf(b, `"%s":%d`, bound, atomic.LoadUint64(&h.buckets[i]))
+	}
+	b.WriteString("}}")
+}
+
+// latencyStats are the latency histograms of a Logger, by sink and Hook
+// type. They implement expvar.Var.
+type latencyStats struct {
+	sinks sync.Map
+	hooks sync.Map
+}
+
+// sinkLatency are the latency histograms of a sink.
+type sinkLatency struct {
+	encode latencyHistogram
+	write  latencyHistogram
+}
+
+// sinkName returns the name of the sink of the kind at the index, or an
+// empty name if s is nil.
+func (s *latencyStats) sinkName(kind string, index int) string {
+	if s == nil {
+		return ""
+	}
+	return kind + "." + strconv.Itoa(index)
+}
+
+// sink returns the histograms of the sink.
+func (s *latencyStats) sink(name string) *sinkLatency {
+	h, ok := s.sinks.Load(name)
+	if !ok {
+		h, _ = s.sinks.LoadOrStore(name, &sinkLatency{})
+	}
+	return h.(*sinkLatency)
+}
+
+// now returns the current time, or the zero time if s is nil.
+func (s *latencyStats) now() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// observeEncode adds the time since start to the encode histogram of the
+// sink if s is not nil.
+func (s *latencyStats) observeEncode(sink string, start time.Time) {
+	if s == nil {
+		return
+	}
+	s.sink(sink).encode.observe(time.Since(start))
+}
+
+// observeWrite adds the time since start to the write histogram of the
+// sink if s is not nil.
+func (s *latencyStats) observeWrite(sink string, start time.Time) {
+	if s == nil {
+		return
+	}
+	s.sink(sink).write.observe(time.Since(start))
+}
+
+// observeHook adds the time since start to the histogram of the Hook type
+// if s is not nil.
+func (s *latencyStats) observeHook(hook Hook, start time.Time) {
+	if s == nil {
+		return
+	}
+	name := fmt.Sprintf("%T", hook)
+	h, ok := s.hooks.Load(name)
+	if !ok {
+		h, _ = s.hooks.LoadOrStore(name, &latencyHistogram{})
+	}
+	h.(*latencyHistogram).observe(time.Since(start))
+}
+
+func (s *latencyStats) String() string {
+	var b bytes.Buffer
+	b.WriteString(`{"sinks":{`)
+	first := true
+	s.sinks.Range(func(name, h interface{}) bool {
+		if !first {
+			b.WriteString(",")
+		}
+		first = false
+		fmt.Fprintf(&b, `%q:{"encode":`, name)
+		h.(*sinkLatency).encode.writeJSON(&b)
+		b.WriteString(`,"write":`)
+		h.(*sinkLatency).write.writeJSON(&b)
+		b.WriteString("}")
+		return true
+	})
+	b.WriteString(`},"hooks":{`)
+	first = true
+	s.hooks.Range(func(name, h interface{}) bool {
+		if !first {
+			b.WriteString(",")
+		}
+		first = false
+		fmt.Fprintf(&b, "%q:", name)
+		h.(*latencyHistogram).writeJSON(&b)
+		return true
+	})
+	b.WriteString("}}")
+	return b.String()
+}
+
+// SetLatencyTracking sets whether the latencies of encoding and writing
+// to each sink and firing each Hook are tracked. Sinks are named output
+// for the output, sink.N for the outputs added with AddOutput and route.N
+// for the global routes. The histograms are published with expvar as
+// slogx_latency, keyed by logger name, with exponential buckets from 1µs
+// to about 1s.
+func (l *Logger) SetLatencyTracking(enabled bool) {
+	l.update(func(c *config) {
+		if !enabled {
+			c.latency = nil
+			return
+		}
+		if c.latency == nil {
+			c.latency = &latencyStats{}
+			publishLatency(c.name, c.latency)
+		}
+	})
+}
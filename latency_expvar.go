@@ -0,0 +1,22 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"expvar"
+	"sync"
+)
+
+var (
+	latencyVars     *expvar.Map
+	latencyVarsOnce sync.Once
+)
+
+// publishLatency publishes the latencyStats of the logger with expvar.
+func publishLatency(name string, stats *latencyStats) {
+	latencyVarsOnce.Do(func() {
+		latencyVars = expvar.NewMap("slogx_latency")
+	})
+	latencyVars.Set(name, stats)
+}
@@ -0,0 +1,7 @@
+//go:build tinygo
+// +build tinygo
+
+package slogx
+
+// publishLatency does nothing, as expvar is not available under TinyGo.
+func publishLatency(name string, stats *latencyStats) {}
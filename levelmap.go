@@ -0,0 +1,45 @@
+package slogx
+
+import "io"
+
+// LevelMap translates the Levels of entries for an output or Hook. Entries
+// translated to NONE are dropped, Levels not in the map are kept.
+type LevelMap map[Level]Level
+
+// translate returns the translated Level and whether the Entry is kept.
+func (m LevelMap) translate(level Level) (Level, bool) {
+	if to, ok := m[level]; ok {
+		level = to
+	}
+	return level, level != NONE
+}
+
+// AddMappedOutput adds an output like AddOutput with the Levels of its
+// entries translated by the LevelMap, e.g. to not send DEBUG entries to a
+// network output.
+func (l *Logger) AddMappedOutput(writer io.Writer, encoder Encoder, levels LevelMap) {
+	l.update(func(c *config) {
+		c.sinks = append(append([]outputSink(nil), c.sinks...), outputSink{output: writer, encoder: encoder, levels: levels})
+	})
+}
+
+// MapLevels returns a Hook firing the Hook with the Levels of entries
+// translated by the LevelMap, e.g. to promote WARNING to ERROR for an
+// alerting Hook.
+func MapLevels(hook Hook, levels LevelMap) Hook {
+	return &levelHook{hook: hook, levels: levels}
+}
+
+type levelHook struct {
+	hook   Hook
+	levels LevelMap
+}
+
+func (h *levelHook) Fire(e Entry) error {
+	level, ok := h.levels.translate(e.Level)
+	if !ok {
+		return nil
+	}
+	e.Level = level
+	return h.hook.Fire(e)
+}
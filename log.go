@@ -0,0 +1,174 @@
+//go:build !slogx_disable
+// +build !slogx_disable
+
+package slogx
+
+import (
+	"context"
+	"fmt"
+)
+
+// disabled reports whether logging is compiled out by the slogx_disable tag.
+const disabled = false
+
+// Log logs a message at the specified Level.
+func (l *Logger) Log(level Level, args ...interface{}) {
+	l.log(level, nil, fmt.Sprint(args...))
+}
+
+// Logf logs a message at the specified Level with formatting.
+func (l *Logger) Logf(level Level, format string, args ...interface{}) {
+	l.log(level, nil, fmt.Sprintf(format, args...))
+}
+
+// LogEntry logs an Entry created elsewhere, e.g. parsed from another log,
+// keeping its time, source location and name. Only the Scope is replaced
+// by that of the Logger.
+func (l *Logger) LogEntry(e Entry) {
+	if !l.enabled(e.Level) {
+		return
+	}
+	e.Scope = l.config().scope
+	l.output(e)
+}
+
+// LogContext logs a message at the specified Level with the Fields of the context.
+func (l *Logger) LogContext(ctx context.Context, level Level, args ...interface{}) {
+	l.log(level, FieldsFromContext(ctx), fmt.Sprint(args...))
+}
+
+// LogContextf logs a message at the specified Level with formatting and the Fields of the context.
+func (l *Logger) LogContextf(ctx context.Context, level Level, format string, args ...interface{}) {
+	l.log(level, FieldsFromContext(ctx), fmt.Sprintf(format, args...))
+}
+
+// Fatal logs a message at FATAL Level and exits.
+func (l *Logger) Fatal(args ...interface{}) {
+	l.log(FATAL, nil, fmt.Sprint(args...))
+	l.exit(0)
+}
+
+// FatalCode logs a message at FATAL Level and exits with the code. A code
+// of zero exits with the code set by SetFatalExitCode.
+func (l *Logger) FatalCode(code int, args ...interface{}) {
+	l.log(FATAL, nil, fmt.Sprint(args...))
+	l.exit(code)
+}
+
+// Fatalf logs a message at FATAL Level with formatting and exits.
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.log(FATAL, nil, fmt.Sprintf(format, args...))
+	l.exit(0)
+}
+
+// FatalContext logs a message at FATAL Level with the Fields of the context and exits.
+func (l *Logger) FatalContext(ctx context.Context, args ...interface{}) {
+	l.log(FATAL, FieldsFromContext(ctx), fmt.Sprint(args...))
+	l.exit(0)
+}
+
+// FatalContextf logs a message at FATAL Level with formatting and the Fields of the context and exits.
+func (l *Logger) FatalContextf(ctx context.Context, format string, args ...interface{}) {
+	l.log(FATAL, FieldsFromContext(ctx), fmt.Sprintf(format, args...))
+	l.exit(0)
+}
+
+// DPanic logs a message at ERROR Level for conditions that should never
+// happen. In development mode it then panics with the message.
+func (l *Logger) DPanic(args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	l.log(ERROR, nil, msg)
+	if l.config().development {
+		panic(msg)
+	}
+}
+
+// DPanicf logs a message at ERROR Level with formatting for conditions that
+// should never happen. In development mode it then panics with the message.
+func (l *Logger) DPanicf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.log(ERROR, nil, msg)
+	if l.config().development {
+		panic(msg)
+	}
+}
+
+// Error logs a message at ERROR Level.
+func (l *Logger) Error(args ...interface{}) {
+	l.log(ERROR, nil, fmt.Sprint(args...))
+}
+
+// Errorf logs a message at ERROR Level with formatting.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.log(ERROR, nil, fmt.Sprintf(format, args...))
+}
+
+// ErrorContext logs a message at ERROR Level with the Fields of the context.
+func (l *Logger) ErrorContext(ctx context.Context, args ...interface{}) {
+	l.log(ERROR, FieldsFromContext(ctx), fmt.Sprint(args...))
+}
+
+// ErrorContextf logs a message at ERROR Level with formatting and the Fields of the context.
+func (l *Logger) ErrorContextf(ctx context.Context, format string, args ...interface{}) {
+	l.log(ERROR, FieldsFromContext(ctx), fmt.Sprintf(format, args...))
+}
+
+// Warning logs a message at WARNING Level.
+func (l *Logger) Warning(args ...interface{}) {
+	l.log(WARNING, nil, fmt.Sprint(args...))
+}
+
+// Warningf logs a message at WARNING Level with formatting.
+func (l *Logger) Warningf(format string, args ...interface{}) {
+	l.log(WARNING, nil, fmt.Sprintf(format, args...))
+}
+
+// WarningContext logs a message at WARNING Level with the Fields of the context.
+func (l *Logger) WarningContext(ctx context.Context, args ...interface{}) {
+	l.log(WARNING, FieldsFromContext(ctx), fmt.Sprint(args...))
+}
+
+// WarningContextf logs a message at WARNING Level with formatting and the Fields of the context.
+func (l *Logger) WarningContextf(ctx context.Context, format string, args ...interface{}) {
+	l.log(WARNING, FieldsFromContext(ctx), fmt.Sprintf(format, args...))
+}
+
+// Info logs a message at INFO Level.
+func (l *Logger) Info(args ...interface{}) {
+	l.log(INFO, nil, fmt.Sprint(args...))
+}
+
+// Infof logs a message at INFO Level with formatting.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.log(INFO, nil, fmt.Sprintf(format, args...))
+}
+
+// InfoContext logs a message at INFO Level with the Fields of the context.
+func (l *Logger) InfoContext(ctx context.Context, args ...interface{}) {
+	l.log(INFO, FieldsFromContext(ctx), fmt.Sprint(args...))
+}
+
+// InfoContextf logs a message at INFO Level with formatting and the Fields of the context.
+func (l *Logger) InfoContextf(ctx context.Context, format string, args ...interface{}) {
+	l.log(INFO, FieldsFromContext(ctx), fmt.Sprintf(format, args...))
+}
+
+// Debug logs a message at DEBUG Level.
+func (l *Logger) Debug(args ...interface{}) {
+	l.log(DEBUG, nil, fmt.Sprint(args...))
+}
+
+// Debugf logs a message at DEBUG Level with formatting.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.log(DEBUG, nil, fmt.Sprintf(format, args...))
+}
+
+// DebugContext logs a message at DEBUG Level with the Fields of the context.
+func (l *Logger) DebugContext(ctx context.Context, args ...interface{}) {
+	l.log(DEBUG, FieldsFromContext(ctx), fmt.Sprint(args...))
+}
+
+// DebugContextf logs a message at DEBUG Level with formatting and the Fields of the context.
+func (l *Logger) DebugContextf(ctx context.Context, format string, args ...interface{}) {
+	l.log(DEBUG, FieldsFromContext(ctx), fmt.Sprintf(format, args...))
+}
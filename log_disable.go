@@ -0,0 +1,108 @@
+//go:build slogx_disable
+// +build slogx_disable
+
+package slogx
+
+import "context"
+
+// disabled reports whether logging is compiled out by the slogx_disable tag.
+const disabled = true
+
+// The logging methods do nothing when built with the slogx_disable tag,
+// except that the Fatal methods still exit. Helpers such as Dump, Table,
+// AccessLog and Event produce nothing either, since no entry is enabled
+// and output drops every entry.
+
+// Log does nothing.
+func (l *Logger) Log(level Level, args ...interface{}) {}
+
+// Logf does nothing.
+func (l *Logger) Logf(level Level, format string, args ...interface{}) {}
+
+// LogEntry does nothing.
+func (l *Logger) LogEntry(e Entry) {}
+
+// LogContext does nothing.
+func (l *Logger) LogContext(ctx context.Context, level Level, args ...interface{}) {}
+
+// LogContextf does nothing.
+func (l *Logger) LogContextf(ctx context.Context, level Level, format string, args ...interface{}) {}
+
+// Fatal exits without logging.
+func (l *Logger) Fatal(args ...interface{}) {
+	l.exit(0)
+}
+
+// FatalCode exits without logging.
+func (l *Logger) FatalCode(code int, args ...interface{}) {
+	l.exit(code)
+}
+
+// Fatalf exits without logging.
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.exit(0)
+}
+
+// FatalContext exits without logging.
+func (l *Logger) FatalContext(ctx context.Context, args ...interface{}) {
+	l.exit(0)
+}
+
+// FatalContextf exits without logging.
+func (l *Logger) FatalContextf(ctx context.Context, format string, args ...interface{}) {
+	l.exit(0)
+}
+
+// DPanic does nothing.
+func (l *Logger) DPanic(args ...interface{}) {}
+
+// DPanicf does nothing.
+func (l *Logger) DPanicf(format string, args ...interface{}) {}
+
+// Error does nothing.
+func (l *Logger) Error(args ...interface{}) {}
+
+// Errorf does nothing.
+func (l *Logger) Errorf(format string, args ...interface{}) {}
+
+// ErrorContext does nothing.
+func (l *Logger) ErrorContext(ctx context.Context, args ...interface{}) {}
+
+// ErrorContextf does nothing.
+func (l *Logger) ErrorContextf(ctx context.Context, format string, args ...interface{}) {}
+
+// Warning does nothing.
+func (l *Logger) Warning(args ...interface{}) {}
+
+// Warningf does nothing.
+func (l *Logger) Warningf(format string, args ...interface{}) {}
+
+// WarningContext does nothing.
+func (l *Logger) WarningContext(ctx context.Context, args ...interface{}) {}
+
+// WarningContextf does nothing.
+func (l *Logger) WarningContextf(ctx context.Context, format string, args ...interface{}) {}
+
+// Info does nothing.
+func (l *Logger) Info(args ...interface{}) {}
+
+// Infof does nothing.
+func (l *Logger) Infof(format string, args ...interface{}) {}
+
+// InfoContext does nothing.
+func (l *Logger) InfoContext(ctx context.Context, args ...interface{}) {}
+
+// InfoContextf does nothing.
+func (l *Logger) InfoContextf(ctx context.Context, format string, args ...interface{}) {}
+
+// Debug does nothing.
+func (l *Logger) Debug(args ...interface{}) {}
+
+// Debugf does nothing.
+func (l *Logger) Debugf(format string, args ...interface{}) {}
+
+// DebugContext does nothing.
+func (l *Logger) DebugContext(ctx context.Context, args ...interface{}) {}
+
+// DebugContextf does nothing.
+func (l *Logger) DebugContextf(ctx context.Context, format string, args ...interface{}) {}
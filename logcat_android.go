@@ -0,0 +1,41 @@
+//go:build android && cgo
+// +build android,cgo
+
+package slogx
+
+/*
+#cgo LDFLAGS: -llog
+#include <android/log.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+var levelToAndroidPriority = map[Level]C.int{
+	DEBUG:   C.ANDROID_LOG_DEBUG,
+	INFO:    C.ANDROID_LOG_INFO,
+	WARNING: C.ANDROID_LOG_WARN,
+	ERROR:   C.ANDROID_LOG_ERROR,
+	FATAL:   C.ANDROID_LOG_FATAL,
+}
+
+// LogcatHook is a Hook that writes entries to the Android log, so they
+// show up in logcat. The tag of each entry is the logger name.
+type LogcatHook struct{}
+
+// NewLogcatHook returns a new LogcatHook.
+func NewLogcatHook() *LogcatHook {
+	return &LogcatHook{}
+}
+
+// Fire writes the message and Fields of the Entry with the priority of
+// its Level.
+func (h *LogcatHook) Fire(e Entry) error {
+	tag := C.CString(e.Name)
+	defer C.free(unsafe.Pointer(tag))
+	msg := C.CString(e.Message + e.Fields.String())
+	defer C.free(unsafe.Pointer(msg))
+	C.__android_log_write(levelToAndroidPriority[e.Level], tag, msg)
+	return nil
+}
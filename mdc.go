@@ -0,0 +1,84 @@
+package slogx
+
+import (
+	"context"
+	"sync"
+)
+
+// MDC is a mapped diagnostic context, a mutable set of Fields carried by a
+// context. Its Fields are added to every entry logged with the context.
+type MDC struct {
+	Mutex  sync.Mutex
+	fields Fields
+}
+
+type mdcKey struct{}
+
+// ContextWithMDC returns a copy of the context carrying a new, empty MDC.
+func ContextWithMDC(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mdcKey{}, &MDC{fields: make(Fields)})
+}
+
+// MDCFromContext returns the MDC carried by the context or nil.
+// All methods of a nil MDC are no-ops.
+func MDCFromContext(ctx context.Context) *MDC {
+	if ctx == nil {
+		return nil
+	}
+	m, _ := ctx.Value(mdcKey{}).(*MDC)
+	return m
+}
+
+// Put sets the value of a key.
+func (m *MDC) Put(key string, value interface{}) {
+	if m == nil {
+		return
+	}
+	m.Mutex.Lock()
+	defer m.Mutex.Unlock()
+	m.fields[key] = value
+}
+
+// Get returns the value of a key.
+func (m *MDC) Get(key string) interface{} {
+	if m == nil {
+		return nil
+	}
+	m.Mutex.Lock()
+	defer m.Mutex.Unlock()
+	return m.fields[key]
+}
+
+// Remove removes a key.
+func (m *MDC) Remove(key string) {
+	if m == nil {
+		return
+	}
+	m.Mutex.Lock()
+	defer m.Mutex.Unlock()
+	delete(m.fields, key)
+}
+
+// Clear removes all keys.
+func (m *MDC) Clear() {
+	if m == nil {
+		return
+	}
+	m.Mutex.Lock()
+	defer m.Mutex.Unlock()
+	m.fields = make(Fields)
+}
+
+// Fields returns a copy of the Fields of the MDC.
+func (m *MDC) Fields() Fields {
+	if m == nil {
+		return nil
+	}
+	m.Mutex.Lock()
+	defer m.Mutex.Unlock()
+	fields := make(Fields, len(m.fields))
+	for k, v := range m.fields {
+		fields[k] = v
+	}
+	return fields
+}
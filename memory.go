@@ -0,0 +1,78 @@
+package slogx
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// MemoryFile is an in-memory log file for targets without a persistent
+// file system, such as wasm instances, whose contents can be read back and
+// saved by the host. It keeps at most MaxSize bytes, if set, dropping the
+// oldest lines.
+type MemoryFile struct {
+	MaxSize int
+	Mutex   sync.Mutex
+	data    []byte
+}
+
+var (
+	memoryFiles      = make(map[string]*MemoryFile)
+	memoryFilesMutex sync.Mutex
+)
+
+// NewMemoryFile returns a new MemoryFile keeping at most maxSize bytes.
+func NewMemoryFile(maxSize int) *MemoryFile {
+	return &MemoryFile{
+		MaxSize: maxSize,
+	}
+}
+
+// OpenMemoryFile returns the MemoryFile with the name, creating it if it
+// does not exist, so the same file can be written and read back from
+// different places.
+func OpenMemoryFile(name string) *MemoryFile {
+	memoryFilesMutex.Lock()
+	defer memoryFilesMutex.Unlock()
+	f, ok := memoryFiles[name]
+	if !ok {
+		f = &MemoryFile{}
+		memoryFiles[name] = f
+	}
+	return f
+}
+
+// Write appends p to the MemoryFile.
+func (f *MemoryFile) Write(p []byte) (int, error) {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	f.data = append(f.data, p...)
+	if f.MaxSize > 0 && len(f.data) > f.MaxSize {
+		drop := len(f.data) - f.MaxSize
+		if i := bytes.IndexByte(f.data[drop:], '\n'); i >= 0 {
+			drop += i + 1
+		}
+		f.data = append(f.data[:0], f.data[drop:]...)
+	}
+	return len(p), nil
+}
+
+// Bytes returns a copy of the contents of the MemoryFile.
+func (f *MemoryFile) Bytes() []byte {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	return append([]byte(nil), f.data...)
+}
+
+// WriteTo writes the contents of the MemoryFile to w.
+func (f *MemoryFile) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(f.Bytes())
+	return int64(n), err
+}
+
+// Reset empties the MemoryFile.
+func (f *MemoryFile) Reset() {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	f.data = nil
+}
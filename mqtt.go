@@ -0,0 +1,324 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MqttHook is a Hook that publishes entries to an MQTT broker.
+// The Topic may contain the verbs ${name} and ${level}.
+//
+// The connection is established on the first entry and reestablished
+// after failures, at most once per Backoff. While the broker is
+// unreachable, entries are appended to the BufferFile if it is set and
+// published once connected again. QoS 0 and 1 are supported.
+type MqttHook struct {
+	Topic       string
+	QoS         byte
+	ClientID    string
+	WillTopic   string
+	WillMessage string
+	BufferFile  string
+	Format      string
+	TimeFormat  string
+	Timeout     time.Duration
+	Backoff     time.Duration
+	Mutex       sync.Mutex
+	addr        string
+	user        *url.Userinfo
+	conn        net.Conn
+	reader      *bufio.Reader
+	packetID    uint16
+	retry       time.Time
+}
+
+type mqttMessage struct {
+	Topic   string `json:"topic"`
+	Payload string `json:"payload"`
+}
+
+// NewMqttHook returns a new MqttHook for the broker at the url.
+func NewMqttHook(rawurl string, topic string) (*MqttHook, error) {
+	if !strings.Contains(rawurl, "://") {
+		rawurl = "tcp://" + rawurl
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "1883")
+	}
+	clientID := u.Query().Get("client_id")
+	if clientID == "" {
+		host, _ := os.Hostname()
+		clientID = fmt.Sprintf("slogx-%s-%d", host, os.Getpid())
+	}
+	return &MqttHook{
+		Topic:      topic,
+		QoS:        1,
+		ClientID:   clientID,
+		Format:     defaultFormat,
+		TimeFormat: DefaultTimeFormat,
+		Timeout:    5 * time.Second,
+		Backoff:    5 * time.Second,
+		addr:       addr,
+		user:       u.User,
+	}, nil
+}
+
+// SetFormat sets the Format used to encode entries.
+func (h *MqttHook) SetFormat(format string) error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	parsed, err := parseFormat(format)
+	if err != nil {
+		return err
+	}
+	h.Format = parsed
+	return nil
+}
+
+// SetQoS sets the QoS level entries are published with, 0 or 1.
+func (h *MqttHook) SetQoS(qos byte) error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	if qos > 1 {
+		return fmt.Errorf("slogx: unsupported QoS '%d'", qos)
+	}
+	h.QoS = qos
+	return nil
+}
+
+// Fire publishes the Entry, buffering it if the broker is unreachable.
+func (h *MqttHook) Fire(e Entry) error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	if h.QoS > 1 {
+		return fmt.Errorf("slogx: unsupported QoS '%d'", h.QoS)
+	}
+	msg := mqttMessage{
+		Topic: strings.NewReplacer(
+			"${name}", e.Name,
+			"${level}", e.Level.String(),
+		).Replace(h.Topic),
+		Payload: formatEntry(h.Format, h.TimeFormat, e),
+	}
+
+	if h.conn == nil && time.Now().Before(h.retry) {
+		if h.BufferFile == "" {
+			return fmt.Errorf("slogx: mqtt broker unreachable")
+		}
+		return h.buffer(msg)
+	}
+
+	connected := h.conn != nil
+	err := h.connect()
+	if err == nil {
+		err = h.publish(msg)
+	}
+	if err != nil {
+		if connected {
+			diagnose(WARNING, Fields{"error": err, "hook": "mqtt"}, "connection lost")
+		}
+		h.disconnect()
+		h.retry = time.Now().Add(h.Backoff)
+		if h.BufferFile == "" {
+			return err
+		}
+		return h.buffer(msg)
+	}
+	return nil
+}
+
+// Close disconnects from the broker.
+func (h *MqttHook) Close() error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	if h.conn == nil {
+		return nil
+	}
+	h.conn.Write([]byte{0xe0, 0x00})
+	return h.disconnect()
+}
+
+// connect connects to the broker and publishes buffered entries.
+func (h *MqttHook) connect() error {
+	if h.conn != nil {
+		return nil
+	}
+	conn, err := net.DialTimeout("tcp", h.addr, h.Timeout)
+	if err != nil {
+		return err
+	}
+	h.conn = conn
+	h.reader = bufio.NewReader(conn)
+
+	var flags byte = 0x02
+	payload := mqttString(h.ClientID)
+	if h.WillTopic != "" {
+		flags |= 0x04 | h.QoS<<3
+		payload = append(payload, mqttString(h.WillTopic)...)
+		payload = append(payload, mqttString(h.WillMessage)...)
+	}
+	if h.user != nil {
+		flags |= 0x80
+		payload = append(payload, mqttString(h.user.Username())...)
+		if pass, ok := h.user.Password(); ok {
+			flags |= 0x40
+			payload = append(payload, mqttString(pass)...)
+		}
+	}
+	header := append(mqttString("MQTT"), 0x04, flags, 0x00, 0x00)
+	if err := h.write(0x10, append(header, payload...)); err != nil {
+		return err
+	}
+	typ, body, err := h.read()
+	if err != nil {
+		return err
+	}
+	if typ != 0x20 || len(body) != 2 || body[1] != 0 {
+		return fmt.Errorf("slogx: mqtt connection refused")
+	}
+	diagnose(INFO, Fields{"hook": "mqtt", "addr": h.addr}, "connected")
+	return h.replay()
+}
+
+func (h *MqttHook) disconnect() error {
+	if h.conn == nil {
+		return nil
+	}
+	err := h.conn.Close()
+	h.conn = nil
+	return err
+}
+
+// publish publishes a message and waits for the acknowledgement with QoS 1.
+func (h *MqttHook) publish(msg mqttMessage) error {
+	body := mqttString(msg.Topic)
+	if h.QoS > 0 {
+		h.packetID++
+		if h.packetID == 0 {
+			h.packetID++
+		}
+		body = append(body, byte(h.packetID>>8), byte(h.packetID))
+	}
+	body = append(body, msg.Payload...)
+	if err := h.write(0x30|h.QoS<<1, body); err != nil {
+		return err
+	}
+	if h.QoS == 0 {
+		return nil
+	}
+	for {
+		typ, body, err := h.read()
+		if err != nil {
+			return err
+		}
+		if typ == 0x40 && len(body) == 2 && binary.BigEndian.Uint16(body) == h.packetID {
+			return nil
+		}
+	}
+}
+
+// buffer appends a message to the BufferFile.
+func (h *MqttHook) buffer(msg mqttMessage) error {
+	f, err := os.OpenFile(h.BufferFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(msg)
+}
+
+// replay publishes and removes the messages in the BufferFile. If
+// publishing fails, the file is replaced with the unsent messages. Lines
+// that cannot be decoded are moved to a file with the suffix .corrupt.
+func (h *MqttHook) replay() error {
+	if h.BufferFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(h.BufferFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	lines := splitLines(data)
+	for i, line := range lines {
+		var msg mqttMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			quarantine(h.BufferFile, line, err)
+			continue
+		}
+		if err := h.publish(msg); err != nil {
+			if err := replaceLines(h.BufferFile, lines[i:]); err != nil {
+				return err
+			}
+			return err
+		}
+	}
+	return replaceLines(h.BufferFile, nil)
+}
+
+func (h *MqttHook) write(typ byte, body []byte) error {
+	h.conn.SetWriteDeadline(time.Now().Add(h.Timeout))
+	packet := []byte{typ}
+	n := len(body)
+	for {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		packet = append(packet, b)
+		if n == 0 {
+			break
+		}
+	}
+	_, err := h.conn.Write(append(packet, body...))
+	return err
+}
+
+func (h *MqttHook) read() (byte, []byte, error) {
+	h.conn.SetReadDeadline(time.Now().Add(h.Timeout))
+	typ, err := h.reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	n, shift := 0, 0
+	for {
+		b, err := h.reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		n |= int(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(h.reader, body); err != nil {
+		return 0, nil, err
+	}
+	return typ & 0xf0, body, nil
+}
+
+func mqttString(s string) []byte {
+	return append([]byte{byte(len(s) >> 8), byte(len(s))}, s...)
+}
@@ -0,0 +1,41 @@
+package slogx
+
+import "strings"
+
+// SetNameWidth sets the maximum width of ${name} and ${namepath} in
+// formatted entries. Longer dotted names are abbreviated from the left to
+// the first letter of their segments, e.g. "a.b.Service" for
+// "app.billing.Service", keeping the last segment. Zero disables
+// abbreviation.
+func (l *Logger) SetNameWidth(width int) {
+	l.update(func(c *config) {
+		c.nameWidth = width
+	})
+}
+
+// shortName returns the last segment of a dotted or slashed name.
+func shortName(name string) string {
+	return name[strings.LastIndexAny(name, "./")+1:]
+}
+
+// namePath returns a dotted name with its segments separated by slashes.
+func namePath(name string) string {
+	return strings.Replace(name, ".", "/", -1)
+}
+
+// abbreviateName abbreviates the segments of a dotted name from the left
+// until it fits into width.
+func abbreviateName(name string, width int) string {
+	if width <= 0 || len(name) <= width {
+		return name
+	}
+	segments := strings.Split(name, ".")
+	length := len(name)
+	for i := 0; i < len(segments)-1 && length > width; i++ {
+		if len(segments[i]) > 1 {
+			length -= len(segments[i]) - 1
+			segments[i] = segments[i][:1]
+		}
+	}
+	return strings.Join(segments, ".")
+}
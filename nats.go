@@ -0,0 +1,187 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NatsHook is a Hook that publishes entries to a NATS subject.
+// The Subject may contain the verbs ${name} and ${level}.
+type NatsHook struct {
+	Subject    string
+	Format     string
+	TimeFormat string
+	JetStream  bool
+	Timeout    time.Duration
+	Mutex      sync.Mutex
+	conn       net.Conn
+	writer     *bufio.Writer
+	inbox      string
+	seq        uint64
+	acks       map[string]chan error
+}
+
+// NewNatsHook returns a new NatsHook connected to the NATS server at the url.
+func NewNatsHook(rawurl string, subject string) (*NatsHook, error) {
+	if !strings.Contains(rawurl, "://") {
+		rawurl = "nats://" + rawurl
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	conn, err := net.DialTimeout("tcp", host, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+
+	h := &NatsHook{
+		Subject:    subject,
+		Format:     defaultFormat,
+		TimeFormat: DefaultTimeFormat,
+		Timeout:    5 * time.Second,
+		conn:       conn,
+		writer:     bufio.NewWriter(conn),
+		inbox:      "_INBOX." + newRequestID(),
+		acks:       make(map[string]chan error),
+	}
+	opts := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "slogx",
+	}
+	if u.User != nil {
+		opts["user"] = u.User.Username()
+		opts["pass"], _ = u.User.Password()
+	}
+	connect, _ := json.Marshal(opts)
+	fmt.Fprintf(h.writer, "CONNECT %s\r\nSUB %s.* 1\r\n", connect, h.inbox)
+	if err := h.writer.Flush(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	go h.read()
+	return h, nil
+}
+
+// read handles messages sent by the server.
+func (h *NatsHook) read() {
+	r := bufio.NewReader(h.conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PING":
+			h.Mutex.Lock()
+			h.writer.WriteString("PONG\r\n")
+			h.writer.Flush()
+			h.Mutex.Unlock()
+		case strings.HasPrefix(line, "MSG "):
+			args := strings.Fields(line)
+			size, _ := strconv.Atoi(args[len(args)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			h.ack(args[1], payload[:size])
+		case strings.HasPrefix(line, "-ERR"):
+			diagnose(ERROR, Fields{"error": strings.TrimSpace(line[4:]), "hook": "nats"}, "server error")
+		}
+	}
+}
+
+// ack delivers the JetStream acknowledgement for the reply subject.
+func (h *NatsHook) ack(subject string, payload []byte) {
+	var resp struct {
+		Error *struct {
+			Description string `json:"description"`
+		} `json:"error"`
+	}
+	var err error
+	if json.Unmarshal(payload, &resp) == nil && resp.Error != nil {
+		err = fmt.Errorf("slogx: jetstream error '%s'", resp.Error.Description)
+	}
+	h.Mutex.Lock()
+	ch, ok := h.acks[subject]
+	delete(h.acks, subject)
+	h.Mutex.Unlock()
+	if ok {
+		ch <- err
+	}
+}
+
+// SetFormat sets the Format used to encode entries.
+func (h *NatsHook) SetFormat(format string) error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	parsed, err := parseFormat(format)
+	if err != nil {
+		return err
+	}
+	h.Format = parsed
+	return nil
+}
+
+// Fire publishes the Entry. With JetStream enabled, Fire waits for the
+// acknowledgement of the server until Timeout.
+func (h *NatsHook) Fire(e Entry) error {
+	subject := strings.NewReplacer(
+		"${name}", strings.Join(strings.Fields(e.Name), "_"),
+		"${level}", e.Level.String(),
+	).Replace(h.Subject)
+
+	h.Mutex.Lock()
+	msg := formatEntry(h.Format, h.TimeFormat, e)
+	var ack chan error
+	var reply string
+	if h.JetStream {
+		h.seq++
+		reply = h.inbox + "." + strconv.FormatUint(h.seq, 10)
+		ack = make(chan error, 1)
+		h.acks[reply] = ack
+		fmt.Fprintf(h.writer, "PUB %s %s %d\r\n%s\r\n", subject, reply, len(msg), msg)
+	} else {
+		fmt.Fprintf(h.writer, "PUB %s %d\r\n%s\r\n", subject, len(msg), msg)
+	}
+	err := h.writer.Flush()
+	h.Mutex.Unlock()
+	if err != nil || ack == nil {
+		return err
+	}
+
+	select {
+	case err := <-ack:
+		return err
+	case <-time.After(h.Timeout):
+		h.Mutex.Lock()
+		delete(h.acks, reply)
+		h.Mutex.Unlock()
+		return fmt.Errorf("slogx: no jetstream acknowledgement for '%s'", subject)
+	}
+}
+
+// Close closes the connection to the server.
+func (h *NatsHook) Close() error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	h.writer.Flush()
+	return h.conn.Close()
+}
@@ -0,0 +1,70 @@
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package slogx
+
+/*
+#include <os/log.h>
+#include <stdlib.h>
+
+static void slogx_os_log(os_log_t log, os_log_type_t type, const char *msg) {
+	os_log_with_type(log, type, "%{public}s", msg);
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+var levelToOSLogType = map[Level]C.os_log_type_t{
+	DEBUG:   C.OS_LOG_TYPE_DEBUG,
+	INFO:    C.OS_LOG_TYPE_INFO,
+	WARNING: C.OS_LOG_TYPE_DEFAULT,
+	ERROR:   C.OS_LOG_TYPE_ERROR,
+	FATAL:   C.OS_LOG_TYPE_FAULT,
+}
+
+// OSLogHook is a Hook that writes entries to Apple's unified logging
+// system, so they show up in Console.app and `log stream`. The category
+// of each entry is the logger name.
+type OSLogHook struct {
+	Subsystem string
+	Mutex     sync.Mutex
+	logs      map[string]C.os_log_t
+}
+
+// NewOSLogHook returns a new OSLogHook for the subsystem, usually in
+// reverse DNS notation like com.example.agent.
+func NewOSLogHook(subsystem string) *OSLogHook {
+	return &OSLogHook{
+		Subsystem: subsystem,
+		logs:      make(map[string]C.os_log_t),
+	}
+}
+
+// Fire writes the message and Fields of the Entry with the log type of
+// its Level.
+func (h *OSLogHook) Fire(e Entry) error {
+	msg := C.CString(e.Message + e.Fields.String())
+	defer C.free(unsafe.Pointer(msg))
+	C.slogx_os_log(h.log(e.Name), levelToOSLogType[e.Level], msg)
+	return nil
+}
+
+// log returns the log object for the category.
+func (h *OSLogHook) log(category string) C.os_log_t {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	if log, ok := h.logs[category]; ok {
+		return log
+	}
+	subsystem := C.CString(h.Subsystem)
+	defer C.free(unsafe.Pointer(subsystem))
+	cat := C.CString(category)
+	defer C.free(unsafe.Pointer(cat))
+	log := C.os_log_create(subsystem, cat)
+	h.logs[category] = log
+	return log
+}
@@ -0,0 +1,29 @@
+package slogx
+
+// OTelFields maps common field keys to the OpenTelemetry semantic
+// conventions used by encoders with OTel mapping enabled. Keys not in the
+// map are kept as they are.
+var OTelFields = map[string]string{
+	"method":      "http.request.method",
+	"status":      "http.response.status_code",
+	"bytes":       "http.response.body.size",
+	"referer":     "http.request.header.referer",
+	"path":        "url.path",
+	"query":       "url.query",
+	"url":         "url.full",
+	"remote_addr": "client.address",
+	"user":        "enduser.id",
+	"user_agent":  "user_agent.original",
+	"error":       "exception.message",
+	"stack":       "exception.stacktrace",
+	"file":        "code.filepath",
+	"line":        "code.lineno",
+}
+
+// otelKey returns the OpenTelemetry key of a field key.
+func otelKey(key string) string {
+	if k, ok := OTelFields[key]; ok {
+		return k
+	}
+	return key
+}
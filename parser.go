@@ -0,0 +1,181 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var fieldRegexp = regexp.MustCompile(`([^\s=]+)=("(?:[^"\\]|\\.)*"|\S*)`)
+
+// Parser reads log entries written with a given Format and TimeFormat, or
+// by a JSONEncoder. Lines starting with "{" are also read as JSON by a
+// Parser for a Format.
+type Parser struct {
+	TimeFormat string
+	re         *regexp.Regexp
+	verbs      []string
+	json       bool
+}
+
+// NewJSONParser returns a new Parser for lines written by a JSONEncoder
+// with RFC 3339 timestamps.
+func NewJSONParser() *Parser {
+	return &Parser{
+		TimeFormat: time.RFC3339Nano,
+		json:       true,
+	}
+}
+
+// NewParser returns a new Parser for a format using the same verbs as SetFormat.
+func NewParser(format string, layout string) (*Parser, error) {
+	tokens, err := scanFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	var verbs []string
+	b.WriteString("^")
+	for _, t := range tokens {
+		if t.verb == "" {
+			b.WriteString(regexp.QuoteMeta(t.literal))
+			continue
+		}
+		b.WriteString(verbPattern(t.verb))
+		verbs = append(verbs, t.verb)
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	return &Parser{
+		TimeFormat: layout,
+		re:         re,
+		verbs:      verbs,
+	}, nil
+}
+
+func verbPattern(verb string) string {
+	switch verb {
+	case "${level}":
+		levels := currentLabels().levels
+		names := make([]string, 0, len(levels))
+		for name := range levels {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+		return "((?i:" + strings.Join(names, "|") + "))"
+	case "${line}":
+		return "(\\d+)"
+	case "${fields}":
+		return `((?: [^\s=]+=(?:"(?:[^"\\]|\\.)*"|\S*))*)`
+	}
+	return "(.*?)"
+}
+
+// Parse parses a single line into an Entry.
+func (p *Parser) Parse(line string) (Entry, error) {
+	if p.json {
+		e, err := decodeJSONEntry([]byte(line), p.TimeFormat)
+		if err != nil {
+			return e, fmt.Errorf("slogx: %v", err)
+		}
+		return e, nil
+	}
+	if strings.HasPrefix(line, "{") {
+		if e, err := decodeJSONEntry([]byte(line), time.RFC3339Nano); err == nil {
+			return e, nil
+		}
+	}
+	var e Entry
+	m := p.re.FindStringSubmatch(line)
+	if m == nil {
+		return e, fmt.Errorf("slogx: line does not match format '%s'", line)
+	}
+	for i, verb := range p.verbs {
+		v := m[i+1]
+		switch verb {
+		case "${time}":
+			t, err := time.ParseInLocation(p.TimeFormat, v, time.Local)
+			if err != nil {
+				return e, fmt.Errorf("slogx: %v", err)
+			}
+			e.Time = t
+		case "${level}":
+			e.Level = ParseLevel(v)
+		case "${file}":
+			e.File = v
+		case "${line}":
+			e.Line, _ = strconv.Atoi(v)
+		case "${name}":
+			e.Name = v
+		case "${message}":
+			e.Message = v
+		case "${fields}":
+			e.Fields = parseFields(v)
+		case "${scope}":
+			e.Scope = v
+		case "${shortname}":
+			if e.Name == "" {
+				e.Name = v
+			}
+		case "${namepath}":
+			if e.Name == "" {
+				e.Name = strings.Replace(v, "/", ".", -1)
+			}
+		}
+	}
+	return e, nil
+}
+
+func parseFields(s string) Fields {
+	if s == "" {
+		return nil
+	}
+	fields := make(Fields)
+	for _, m := range fieldRegexp.FindAllStringSubmatch(s, -1) {
+		v := m[2]
+		if strings.HasPrefix(v, "\"") {
+			if unquoted, err := strconv.Unquote(v); err == nil {
+				v = unquoted
+			}
+		}
+		fields[m[1]] = v
+	}
+	return fields
+}
+
+// ParseAll parses all entries from r. Lines that do not match the format are
+// treated as continuations of the message of the previous entry.
+func (p *Parser) ParseAll(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		e, err := p.Parse(s.Text())
+		if err != nil {
+			if len(entries) == 0 {
+				return nil, err
+			}
+			entries[len(entries)-1].Message += "\n" + s.Text()
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	return entries, nil
+}
+
+// Parser returns a Parser for lines rendered with the Format and the time
+// layout.
+func (f Format) Parser(layout string) (*Parser, error) {
+	return NewParser(f.spec, layout)
+}
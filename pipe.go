@@ -0,0 +1,128 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// PipeWriter is an io.Writer that streams output to the stdin of a
+// command, like svlogd or multilog. If the command exits, it is restarted
+// on the next write, waiting at least RestartDelay between starts. Writes
+// block while the command does not read its stdin.
+type PipeWriter struct {
+	Name         string
+	Args         []string
+	RestartDelay time.Duration
+	Mutex        sync.Mutex
+	stdin        io.WriteCloser
+	exited       chan struct{}
+	started      time.Time
+}
+
+// NewPipeWriter starts the command and returns a new PipeWriter writing
+// to its stdin.
+func NewPipeWriter(name string, args ...string) (*PipeWriter, error) {
+	w := &PipeWriter{
+		Name:         name,
+		Args:         args,
+		RestartDelay: time.Second,
+	}
+	if err := w.start(); err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	return w, nil
+}
+
+func openExecSink(u *url.URL) (io.Writer, error) {
+	name := u.Opaque
+	if name == "" {
+		name = u.Host + u.Path
+	}
+	return NewPipeWriter(name, u.Query()["arg"]...)
+}
+
+func (w *PipeWriter) start() error {
+	cmd := exec.Command(w.Name, w.Args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	w.stdin = stdin
+	w.exited = make(chan struct{})
+	w.started = time.Now()
+	go func(exited chan struct{}) {
+		if err := cmd.Wait(); err != nil {
+			diagnose(WARNING, Fields{"command": w.Name, "error": err}, "command exited")
+		} else {
+			diagnose(INFO, Fields{"command": w.Name}, "command exited")
+		}
+		close(exited)
+	}(w.exited)
+	return nil
+}
+
+// running reports whether the command has not exited.
+func (w *PipeWriter) running() bool {
+	select {
+	case <-w.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// restart starts the command again after the RestartDelay.
+func (w *PipeWriter) restart() error {
+	w.stdin.Close()
+	time.Sleep(time.Until(w.started.Add(w.RestartDelay)))
+	return w.start()
+}
+
+// Write writes p to the stdin of the command, restarting it if it has
+// exited.
+func (w *PipeWriter) Write(p []byte) (int, error) {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	if w.exited == nil {
+		return 0, fmt.Errorf("slogx: pipe closed")
+	}
+	if !w.running() {
+		if err := w.restart(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.stdin.Write(p)
+	if err != nil && !w.running() {
+		if err := w.restart(); err != nil {
+			return 0, err
+		}
+		return w.stdin.Write(p)
+	}
+	return n, err
+}
+
+// Close closes the stdin of the command and waits for it to exit.
+func (w *PipeWriter) Close() error {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	if w.exited == nil {
+		return nil
+	}
+	err := w.stdin.Close()
+	<-w.exited
+	w.exited = nil
+	return err
+}
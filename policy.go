@@ -0,0 +1,40 @@
+package slogx
+
+// AllowFields sets the only field keys kept on entries of the Logger. All
+// other fields are removed before encoding. Without keys, all fields are
+// allowed.
+func (l *Logger) AllowFields(keys ...string) {
+	l.update(func(c *config) {
+		c.allowed = keySet(keys)
+	})
+}
+
+// DenyFields sets field keys that are removed from entries of the Logger
+// before encoding.
+func (l *Logger) DenyFields(keys ...string) {
+	l.update(func(c *config) {
+		c.denied = keySet(keys)
+	})
+}
+
+func keySet(keys []string) map[string]bool {
+	if len(keys) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+func (c *config) filterFields(e *Entry) {
+	fields := make(Fields, len(e.Fields))
+	for k, v := range e.Fields {
+		if (c.allowed != nil && !c.allowed[k]) || c.denied[k] {
+			continue
+		}
+		fields[k] = v
+	}
+	e.Fields = fields
+}
@@ -0,0 +1,60 @@
+package slogx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetTimePrecision sets the precision of the times of formatted entries,
+// e.g. time.Millisecond for three fractional digits, replacing any
+// fractional seconds of the time format. Zero keeps the time format as is.
+func (l *Logger) SetTimePrecision(precision time.Duration) {
+	l.update(func(c *config) {
+		c.precision = precision
+	})
+}
+
+// precisionOption returns the duration of the encoder option precision.
+func precisionOption(options map[string]string) (time.Duration, error) {
+	v, ok := options["precision"]
+	if !ok {
+		return 0, nil
+	}
+	precision, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("slogx: invalid precision '%s'", v)
+	}
+	return precision, nil
+}
+
+// withPrecision returns the layout with the fractional seconds of the
+// precision after its seconds. Layouts without seconds are returned as is.
+func withPrecision(layout string, precision time.Duration) string {
+	if precision <= 0 {
+		return layout
+	}
+	i := strings.Index(layout, "05")
+	if i < 0 {
+		return layout
+	}
+	i += 2
+	end := i
+	separator := "."
+	if end < len(layout) && (layout[end] == '.' || layout[end] == ',') {
+		separator = layout[end : end+1]
+		end++
+		for end < len(layout) && (layout[end] == '0' || layout[end] == '9') {
+			end++
+		}
+	}
+	digits := 0
+	for d := time.Second; d > precision && digits < 9; d /= 10 {
+		digits++
+	}
+	fraction := ""
+	if digits > 0 {
+		fraction = separator + strings.Repeat("0", digits)
+	}
+	return layout[:i] + fraction + layout[end:]
+}
@@ -0,0 +1,137 @@
+package slogx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Prepared logs messages of a fixed format at a fixed Level. The format is
+// parsed once and messages are built in pooled buffers. Formats with
+// explicit argument indexes or * widths, and calls with extra arguments,
+// are formatted with fmt.Sprintf.
+type Prepared struct {
+	logger   *Logger
+	level    Level
+	format   string
+	literals []string
+	verbs    []string
+	indexed  bool
+	pool     sync.Pool
+}
+
+// Prepare returns a Prepared for logging messages of the format at the
+// specified Level, using the verbs of fmt.Sprintf.
+func (l *Logger) Prepare(level Level, format string) *Prepared {
+	p := &Prepared{
+		logger: l,
+		level:  level,
+		format: format,
+	}
+	p.pool.New = func() interface{} {
+		b := make([]byte, 0, 2*len(format))
+		return &b
+	}
+	literal := strings.Builder{}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			literal.WriteByte(format[i])
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			literal.WriteByte('%')
+			i++
+			continue
+		}
+		end := i + 1
+		for end < len(format) && strings.IndexByte("+-# 0123456789.[]*", format[end]) >= 0 {
+			end++
+		}
+		if end == len(format) {
+			literal.WriteString(format[i:])
+			break
+		}
+		verb := format[i : end+1]
+		if strings.ContainsAny(verb, "[*") {
+			p.indexed = true
+		}
+		p.literals = append(p.literals, literal.String())
+		p.verbs = append(p.verbs, verb)
+		literal.Reset()
+		i = end
+	}
+	p.literals = append(p.literals, literal.String())
+	return p
+}
+
+// Log logs a message of the prepared format with the arguments.
+func (p *Prepared) Log(args ...interface{}) {
+	if !p.logger.enabled(p.level) {
+		return
+	}
+	p.logger.log(p.level, nil, p.message(args))
+}
+
+// LogFields logs a message of the prepared format with the arguments and
+// the Fields.
+func (p *Prepared) LogFields(fields Fields, args ...interface{}) {
+	if !p.logger.enabled(p.level) {
+		return
+	}
+	p.logger.log(p.level, fields, p.message(args))
+}
+
+// message returns the prepared format applied to the arguments.
+func (p *Prepared) message(args []interface{}) string {
+	if p.indexed || len(args) > len(p.verbs) {
+		return fmt.Sprintf(p.format, args...)
+	}
+	buf := p.pool.Get().(*[]byte)
+	b := (*buf)[:0]
+	for i, verb := range p.verbs {
+		b = append(b, p.literals[i]...)
+		if i >= len(args) {
+			b = append(b, "%!"...)
+			b = append(b, verb[len(verb)-1])
+			b = append(b, "(MISSING)"...)
+			continue
+		}
+		b = appendArg(b, verb, args[i])
+	}
+	b = append(b, p.literals[len(p.verbs)]...)
+	msg := string(b)
+	*buf = b
+	p.pool.Put(buf)
+	return msg
+}
+
+// appendArg appends the argument formatted with the verb, avoiding fmt for
+// the common verbs of strings and integers.
+func appendArg(b []byte, verb string, arg interface{}) []byte {
+	switch verb {
+	case "%s", "%v":
+		switch v := arg.(type) {
+		case string:
+			return append(b, v...)
+		case int:
+			return strconv.AppendInt(b, int64(v), 10)
+		case int64:
+			return strconv.AppendInt(b, v, 10)
+		}
+	case "%d":
+		switch v := arg.(type) {
+		case int:
+			return strconv.AppendInt(b, int64(v), 10)
+		case int64:
+			return strconv.AppendInt(b, v, 10)
+		case uint64:
+			return strconv.AppendUint(b, v, 10)
+		}
+	case "%q":
+		if v, ok := arg.(string); ok {
+			return strconv.AppendQuote(b, v)
+		}
+	}
+	return append(b, fmt.Sprintf(verb, arg)...)
+}
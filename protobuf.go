@@ -0,0 +1,119 @@
+package slogx
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ProtobufEncoder encodes entries as length-prefixed protobuf records of
+// the Entry message defined in entry.proto. Field values that are not
+// numbers or booleans are encoded as strings.
+type ProtobufEncoder struct{}
+
+// NewProtobufEncoder returns a new ProtobufEncoder.
+func NewProtobufEncoder() *ProtobufEncoder {
+	return &ProtobufEncoder{}
+}
+
+func newProtobufEncoder(options map[string]string) (Encoder, error) {
+	return NewProtobufEncoder(), nil
+}
+
+func (enc *ProtobufEncoder) binary() {}
+
+// Encode returns the Entry as a protobuf record prefixed with its length.
+func (enc *ProtobufEncoder) Encode(e Entry) ([]byte, error) {
+	var b []byte
+	if !e.Time.IsZero() {
+		b = appendUint64LE(appendVarint(b, 1<<3|1), uint64(e.Time.UnixNano()))
+	}
+	if e.Level != NONE {
+		b = appendVarint(appendVarint(b, 2<<3), uint64(e.Level))
+	}
+	b = appendProtoString(b, 3, e.Name)
+	b = appendProtoString(b, 4, e.File)
+	if e.Line != 0 {
+		b = appendVarint(appendVarint(b, 5<<3), uint64(e.Line))
+	}
+	b = appendProtoString(b, 6, e.Message)
+	b = appendProtoString(b, 7, e.Scope)
+	for _, k := range e.Fields.sortedKeys() {
+		var entry []byte
+		entry = appendProtoString(entry, 1, k)
+		entry = appendProtoBytes(entry, 2, protoValue(e.Fields[k]))
+		b = appendProtoBytes(b, 8, entry)
+	}
+	return append(appendVarint(nil, uint64(len(b))), b...), nil
+}
+
+// protoValue returns v encoded as a Value message.
+func protoValue(v interface{}) []byte {
+	var n int64
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return []byte{5 << 3, 1}
+		}
+		return []byte{5 << 3, 0}
+	case int:
+		n = int64(v)
+	case int8:
+		n = int64(v)
+	case int16:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case uint:
+		return appendVarint([]byte{3 << 3}, uint64(v))
+	case uint8:
+		return appendVarint([]byte{3 << 3}, uint64(v))
+	case uint16:
+		return appendVarint([]byte{3 << 3}, uint64(v))
+	case uint32:
+		return appendVarint([]byte{3 << 3}, uint64(v))
+	case uint64:
+		return appendVarint([]byte{3 << 3}, v)
+	case float32:
+		return appendUint64LE([]byte{4<<3 | 1}, math.Float64bits(float64(v)))
+	case float64:
+		return appendUint64LE([]byte{4<<3 | 1}, math.Float64bits(v))
+	case time.Duration:
+		return appendProtoString(nil, 1, v.String())
+	case rawValuer:
+		return protoValue(v.raw())
+	case error:
+		return appendProtoString(nil, 1, v.Error())
+	default:
+		return appendProtoString(nil, 1, fmt.Sprint(v))
+	}
+	return appendVarint([]byte{2 << 3}, uint64(n<<1)^uint64(n>>63))
+}
+
+func appendProtoString(b []byte, field uint64, s string) []byte {
+	if s == "" {
+		return b
+	}
+	return append(appendVarint(appendVarint(b, field<<3|2), uint64(len(s))), s...)
+}
+
+func appendProtoBytes(b []byte, field uint64, p []byte) []byte {
+	return append(appendVarint(appendVarint(b, field<<3|2), uint64(len(p))), p...)
+}
+
+func appendVarint(b []byte, n uint64) []byte {
+	for n >= 0x80 {
+		b = append(b, byte(n)|0x80)
+		n >>= 7
+	}
+	return append(b, byte(n))
+}
+
+func appendUint64LE(b []byte, n uint64) []byte {
+	for i := 0; i < 8; i++ {
+		b = append(b, byte(n>>(8*i)))
+	}
+	return b
+}
@@ -0,0 +1,94 @@
+package slogx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Query selects entries matching a set of conditions.
+type Query struct {
+	level    Level
+	name     string
+	contains string
+	since    time.Time
+	until    time.Time
+	fields   Fields
+}
+
+// Filter returns a Query matching entries at the specified Level or above.
+func Filter(level Level) *Query {
+	return &Query{level: level}
+}
+
+// Name restricts the Query to entries of the Logger with the given name.
+func (q *Query) Name(name string) *Query {
+	q.name = name
+	return q
+}
+
+// Contains restricts the Query to entries whose message contains s.
+func (q *Query) Contains(s string) *Query {
+	q.contains = s
+	return q
+}
+
+// Since restricts the Query to entries logged at or after t.
+func (q *Query) Since(t time.Time) *Query {
+	q.since = t
+	return q
+}
+
+// Until restricts the Query to entries logged before t.
+func (q *Query) Until(t time.Time) *Query {
+	q.until = t
+	return q
+}
+
+// WithField restricts the Query to entries with the field key set to
+// value. Values are compared by their formatted form, so entries parsed
+// from text match typed values.
+func (q *Query) WithField(key string, value interface{}) *Query {
+	if q.fields == nil {
+		q.fields = Fields{}
+	}
+	q.fields[key] = value
+	return q
+}
+
+// Match reports whether the Entry matches the Query.
+func (q *Query) Match(e Entry) bool {
+	if e.Level == NONE || e.Level > q.level {
+		return false
+	}
+	if q.name != "" && e.Name != q.name {
+		return false
+	}
+	if q.contains != "" && !strings.Contains(e.Message, q.contains) {
+		return false
+	}
+	if !q.since.IsZero() && e.Time.Before(q.since) {
+		return false
+	}
+	if !q.until.IsZero() && !e.Time.Before(q.until) {
+		return false
+	}
+	for k, v := range q.fields {
+		f, ok := e.Fields[k]
+		if !ok || fmt.Sprint(f) != fmt.Sprint(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the entries matching the Query.
+func (q *Query) Apply(entries []Entry) []Entry {
+	var matched []Entry
+	for _, e := range entries {
+		if q.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
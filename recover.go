@@ -0,0 +1,84 @@
+package slogx
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// SetRepanic sets whether Go and Recover panic again after logging a
+// panic, so the process still crashes as it would without them.
+func (l *Logger) SetRepanic(enabled bool) {
+	l.update(func(c *config) {
+		c.repanic = enabled
+	})
+}
+
+// Go runs fn in a new goroutine. If fn panics, the panic is logged at ERROR
+// Level with its stack trace.
+func Go(logger *Logger, fn func()) {
+	go func() {
+		defer logger.recoverPanic()
+		fn()
+	}()
+}
+
+// recoverPanic logs a panic of the calling goroutine and panics again if
+// the Logger re-panics.
+func (l *Logger) recoverPanic() {
+	v := recover()
+	if v == nil {
+		return
+	}
+	l.logPanic(v, Fields{})
+	if l.config().repanic {
+		panic(v)
+	}
+}
+
+// logPanic logs the panic value with the stack trace of the panicking
+// goroutine. The file and line are those of the panic.
+func (l *Logger) logPanic(v interface{}, fields Fields) {
+	if !l.enabled(ERROR) {
+		return
+	}
+	c := l.config()
+	stack := string(debug.Stack())
+	msg := fmt.Sprintf("panic: %v", v)
+	if _, ok := c.encoder.(*JSONEncoder); ok {
+		fields["stack"] = stack
+	} else {
+		msg += "\n" + strings.TrimSuffix(stack, "\n")
+	}
+	file, line := panicSite()
+	l.output(Entry{
+		Time:    c.now(),
+		Level:   ERROR,
+		File:    filepath.Base(file),
+		Line:    line,
+		Name:    c.name,
+		Message: msg,
+		Fields:  fields,
+		Scope:   c.scope,
+	})
+}
+
+// panicSite returns the file and line of the function that panicked.
+func panicSite() (string, int) {
+	pc := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+	panicking := false
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.gopanic" {
+			panicking = true
+		} else if panicking && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package slogx
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Route copies entries to an Output. An Entry matches if it carries the
+// Tag, added with Logger.Tag, a field classified with the Class, added with
+// ClassifyField, and all Fields with the given formatted values. An empty
+// Route matches all entries.
+type Route struct {
+	Tag     string
+	Class   string
+	Fields  map[string]string
+	Output  io.Writer
+	Encoder Encoder
+}
+
+var (
+	routes      atomic.Value
+	routesMutex sync.Mutex
+)
+
+// AddRoute adds a Route applied to the entries of all loggers. Entries
+// copied by a Route are encoded with its Encoder, or the Format of the
+// Logger if it is nil. A Route without an Output is rejected.
+func AddRoute(r Route) error {
+	if r.Output == nil {
+		return fmt.Errorf("slogx: route without output")
+	}
+	routesMutex.Lock()
+	defer routesMutex.Unlock()
+	current, _ := routes.Load().([]Route)
+	routes.Store(append(append([]Route(nil), current...), r))
+	return nil
+}
+
+// match reports whether the Entry matches the Route.
+func (r Route) match(e Entry) bool {
+	if r.Tag != "" && !hasTag(e, r.Tag) {
+		return false
+	}
+	if r.Class != "" && !hasClass(e, r.Class) {
+		return false
+	}
+	for k, v := range r.Fields {
+		value, ok := e.Fields[k]
+		if !ok || fmt.Sprint(value) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Tag returns a copy of the Logger adding the tags to the field tags of
+// its entries, for routing them with a Route. The copy is not registered.
+func (l *Logger) Tag(tags ...string) *Logger {
+	c := l.clone()
+	c.update(func(cfg *config) {
+		cfg.tags = append(append([]string(nil), cfg.tags...), tags...)
+	})
+	return c
+}
+
+func hasTag(e Entry, tag string) bool {
+	tags, _ := e.Fields["tags"].([]string)
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package slogx
+
+import (
+	"sync"
+	"time"
+)
+
+type sampler struct {
+	tick       time.Duration
+	first      int
+	thereafter int
+	mutex      sync.Mutex
+	window     time.Time
+	counts     map[sampleKey]int
+}
+
+type sampleKey struct {
+	level   Level
+	message string
+}
+
+// SetSampling enables sampling of entries. Within every tick, the first
+// entries with the same Level and message are logged, after which only
+// every thereafter-th entry is logged. A tick of zero disables sampling.
+func (l *Logger) SetSampling(tick time.Duration, first int, thereafter int) {
+	l.update(func(c *config) {
+		if tick <= 0 {
+			c.sampler = nil
+			return
+		}
+		c.sampler = &sampler{
+			tick:       tick,
+			first:      first,
+			thereafter: thereafter,
+			counts:     make(map[sampleKey]int),
+		}
+	})
+}
+
+// sample reports whether the Entry should be logged.
+func (s *sampler) sample(e Entry) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if e.Time.Sub(s.window) >= s.tick {
+		s.window = e.Time
+		s.counts = make(map[sampleKey]int)
+	}
+	key := sampleKey{level: e.Level, message: e.Message}
+	n := s.counts[key] + 1
+	s.counts[key] = n
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
@@ -0,0 +1,133 @@
+package slogx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schema describes the fields every entry of a Logger must conform to, so
+// logging conventions like every entry carrying a request_id are enforced.
+// It is only validated in development mode. Violations are reported by the
+// diagnostics logger or, with Panic, panic.
+type Schema struct {
+	Fields map[string]FieldSchema
+	Panic  bool
+}
+
+// FieldSchema describes a field of a Schema. Type is one of string,
+// number, bool, time, duration or error, and any type if empty. Enum lists
+// the allowed values as formatted by fmt.Sprint, and any value if empty.
+type FieldSchema struct {
+	Required bool
+	Type     string
+	Enum     []string
+}
+
+var schemaTypes = map[string]func(v interface{}) bool{
+	"string": func(v interface{}) bool {
+		return reflect.ValueOf(v).Kind() == reflect.String
+	},
+	"number": func(v interface{}) bool {
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	},
+	"bool": func(v interface{}) bool {
+		return reflect.ValueOf(v).Kind() == reflect.Bool
+	},
+	"time": func(v interface{}) bool {
+		_, ok := v.(time.Time)
+		return ok
+	},
+	"duration": func(v interface{}) bool {
+		switch v.(type) {
+		case time.Duration, Duration:
+			return true
+		}
+		return false
+	},
+	"error": func(v interface{}) bool {
+		_, ok := v.(error)
+		return ok
+	},
+}
+
+// SetSchema sets the Schema validated against entries of the Logger in
+// development mode. A nil Schema disables validation.
+func (l *Logger) SetSchema(s *Schema) error {
+	if s != nil {
+		for _, key := range s.keys() {
+			if t := s.Fields[key].Type; t != "" && schemaTypes[t] == nil {
+				return fmt.Errorf("slogx: invalid type '%s' of field '%s'", t, key)
+			}
+		}
+	}
+	l.update(func(c *config) {
+		c.schema = s
+	})
+	return nil
+}
+
+// Validate returns an error listing the violations of the Schema by the
+// Entry, or nil if it conforms.
+func (s *Schema) Validate(e Entry) error {
+	var violations []string
+	for _, key := range s.keys() {
+		f := s.Fields[key]
+		v, ok := e.Fields[key]
+		if !ok {
+			if f.Required {
+				violations = append(violations, fmt.Sprintf("missing field '%s'", key))
+			}
+			continue
+		}
+		if f.Type != "" && (v == nil || !schemaTypes[f.Type](v)) {
+			violations = append(violations, fmt.Sprintf("field '%s' is %T, not %s", key, v, f.Type))
+			continue
+		}
+		if len(f.Enum) > 0 && !contains(f.Enum, fmt.Sprint(v)) {
+			violations = append(violations, fmt.Sprintf("field '%s' is '%v', not one of %s", key, v, strings.Join(f.Enum, ", ")))
+		}
+	}
+	if violations != nil {
+		return fmt.Errorf("slogx: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+func (s *Schema) keys() []string {
+	keys := make([]string, 0, len(s.Fields))
+	for k := range s.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validate reports the violations of the Schema by the Entry.
+func (l *Logger) validate(s *Schema, e Entry) {
+	err := s.Validate(e)
+	if err == nil {
+		return
+	}
+	if s.Panic {
+		panic(err)
+	}
+	l.diagnose(WARNING, Fields{"error": err, "message": e.Message}, "schema violated")
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
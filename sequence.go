@@ -0,0 +1,30 @@
+package slogx
+
+import "sync/atomic"
+
+// sequence is the last sequence number stamped on an Entry.
+var sequence uint64
+
+// SetSequence sets whether entries are stamped with the field seq, a
+// sequence number increasing across all loggers in the order entries are
+// logged. It restores the order of entries interleaved by asynchronous
+// outputs or merged from several outputs.
+func (l *Logger) SetSequence(enabled bool) {
+	l.update(func(c *config) {
+		c.sequence = enabled
+	})
+}
+
+// stamp adds the next sequence number to the Entry unless it has one, as
+// entries flushed from a Buffer do.
+func stamp(e *Entry) {
+	if _, ok := e.Fields["seq"]; ok {
+		return
+	}
+	fields := make(Fields, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields["seq"] = atomic.AddUint64(&sequence, 1)
+	e.Fields = fields
+}
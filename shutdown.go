@@ -0,0 +1,111 @@
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShutdownTimeout is how long Fatal waits for Shutdown before exiting.
+var ShutdownTimeout = 5 * time.Second
+
+// shutdownFunc is a function registered with OnFatal or OnShutdown.
+type shutdownFunc struct {
+	fn    func()
+	fatal bool
+}
+
+var (
+	shutdownFuncs []shutdownFunc
+	shutdownMutex sync.Mutex
+)
+
+// OnFatal registers a function run by Shutdown and before Fatal exits,
+// e.g. to flush traces or metrics and release locks. Functions run in
+// reverse order of registration.
+func OnFatal(fn func()) {
+	shutdownMutex.Lock()
+	defer shutdownMutex.Unlock()
+	shutdownFuncs = append(shutdownFuncs, shutdownFunc{fn: fn, fatal: true})
+}
+
+// OnShutdown registers a function run only by Shutdown, not before Fatal
+// exits, e.g. to drain connections on a graceful shutdown. Functions run
+// in reverse order of registration, together with those of OnFatal.
+func OnShutdown(fn func()) {
+	shutdownMutex.Lock()
+	defer shutdownMutex.Unlock()
+	shutdownFuncs = append(shutdownFuncs, shutdownFunc{fn: fn})
+}
+
+// Shutdown runs the functions registered with OnShutdown and OnFatal, then
+// closes the Hooks of all registered and tenant loggers that implement
+// io.Closer, flushing their batches, and flushes their outputs. It returns
+// when they are done or the context is done. The functions are run only
+// once.
+func Shutdown(ctx context.Context) error {
+	return shutdown(ctx, false)
+}
+
+// shutdown runs the registered functions, only those of OnFatal if fatal
+// is set, and closes the Hooks.
+func shutdown(ctx context.Context, fatal bool) error {
+	shutdownMutex.Lock()
+	funcs := shutdownFuncs
+	shutdownFuncs = nil
+	shutdownMutex.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		for i := len(funcs) - 1; i >= 0; i-- {
+			if funcs[i].fatal || !fatal {
+				funcs[i].fn()
+			}
+		}
+		done <- closeHooks()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("slogx: shutdown: %v", ctx.Err())
+	}
+}
+
+// closeHooks closes the Hooks of all registered and tenant loggers that
+// implement io.Closer and flushes their outputs. Hooks shared by loggers
+// are closed once.
+func closeHooks() error {
+	var hooks []Hook
+	var errs []string
+	for _, l := range allLoggers() {
+		c := l.config()
+		hooks = append(hooks, c.hooks...)
+		errs = append(errs, c.flushOutputs()...)
+	}
+
+	closed := make(map[io.Closer]bool)
+	for _, hook := range hooks {
+		c, ok := hook.(io.Closer)
+		if !ok {
+			continue
+		}
+		if reflect.TypeOf(c).Comparable() {
+			if closed[c] {
+				continue
+			}
+			closed[c] = true
+		}
+		if err := c.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("slogx: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build (!aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris) || tinygo
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris tinygo
+
+package slogx
+
+import "time"
+
+// HandleSignals is a no-op on platforms without SIGUSR1 and SIGQUIT.
+func HandleSignals(logger *Logger, duration time.Duration) (stop func()) {
+	return func() {}
+}
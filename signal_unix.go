@@ -0,0 +1,76 @@
+//go:build (aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris) && !tinygo
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+// +build !tinygo
+
+package slogx
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// HandleSignals installs diagnostic signal handlers until stop is called.
+// SIGUSR1 raises all registered and tenant loggers to DEBUG Level for the
+// duration, after which their previous Levels are restored unless they
+// were changed meanwhile. SIGQUIT logs a goroutine dump through the Logger
+// at ERROR Level instead of exiting.
+func HandleSignals(logger *Logger, duration time.Duration) (stop func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGUSR1, syscall.SIGQUIT)
+	done := make(chan struct{})
+	go func() {
+		var restore map[*Logger]Level
+		var timer <-chan time.Time
+		for {
+			select {
+			case sig := <-c:
+				if sig == syscall.SIGQUIT {
+					logger.logGoroutines()
+					continue
+				}
+				if restore == nil {
+					restore = raiseAll(DEBUG)
+				}
+				timer = time.After(duration)
+			case <-timer:
+				restoreAll(restore, DEBUG)
+				restore, timer = nil, nil
+			case <-done:
+				signal.Stop(c)
+				restoreAll(restore, DEBUG)
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+func raiseAll(level Level) map[*Logger]Level {
+	list := allLoggers()
+	previous := make(map[*Logger]Level, len(list))
+	for _, l := range list {
+		previous[l] = l.GetLevel()
+		l.SetLevel(level)
+	}
+	return previous
+}
+
+// restoreAll restores the previous Levels of loggers still at the raised
+// Level, keeping Levels set in the meantime.
+func restoreAll(previous map[*Logger]Level, raised Level) {
+	for l, level := range previous {
+		l.update(func(c *config) {
+			if c.level == raised {
+				c.level = level
+			}
+		})
+	}
+}
+
+func (l *Logger) logGoroutines() {
+	l.log(ERROR, nil, "goroutine dump\n"+string(goroutineDump()))
+}
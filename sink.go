@@ -0,0 +1,231 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SinkFactory returns a new writer for a sink URL.
+type SinkFactory func(u *url.URL) (io.Writer, error)
+
+var (
+	sinks      = make(map[string]SinkFactory)
+	sinksMutex sync.Mutex
+)
+
+func init() {
+	RegisterSink("stdout", func(u *url.URL) (io.Writer, error) {
+		return os.Stdout, nil
+	})
+	RegisterSink("stderr", func(u *url.URL) (io.Writer, error) {
+		return os.Stderr, nil
+	})
+	RegisterSink("file", openFileSink)
+	RegisterSink("tcp", openNetSink)
+	RegisterSink("udp", openNetSink)
+	RegisterSink("unix", openNetSink)
+	RegisterSink("exec", openExecSink)
+	RegisterSink("memory", openMemorySink)
+}
+
+// RegisterSink registers a SinkFactory for a URL scheme, replacing any
+// factory already registered for the scheme.
+func RegisterSink(scheme string, factory SinkFactory) {
+	sinksMutex.Lock()
+	defer sinksMutex.Unlock()
+	sinks[scheme] = factory
+}
+
+// OpenSink returns a new writer for a sink URL using the factory registered
+// for its scheme. A URL without a scheme is opened as a file.
+func OpenSink(rawurl string) (io.Writer, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	if u.Scheme == "" {
+		u.Scheme = "file"
+	}
+	sinksMutex.Lock()
+	factory, ok := sinks[u.Scheme]
+	sinksMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("slogx: unknown sink scheme '%s'", u.Scheme)
+	}
+	w, err := factory(u)
+	if err != nil {
+		return nil, fmt.Errorf("slogx: %v", err)
+	}
+	return w, nil
+}
+
+func openFileSink(u *url.URL) (io.Writer, error) {
+	path := u.Host + u.Path
+	if u.Opaque != "" {
+		path = u.Opaque
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if v := u.Query().Get("framed"); v != "" {
+		framed, err := strconv.ParseBool(v)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("invalid framed '%s'", v)
+		}
+		if framed {
+			return NewFrameWriter(f), nil
+		}
+	}
+	return f, nil
+}
+
+func openMemorySink(u *url.URL) (io.Writer, error) {
+	f := OpenMemoryFile(u.Host + u.Path)
+	if v := u.Query().Get("max_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_size '%s'", v)
+		}
+		f.Mutex.Lock()
+		f.MaxSize = size
+		f.Mutex.Unlock()
+	}
+	return f, nil
+}
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3,
+	"auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var syslogSeverities = map[string]int{
+	"emerg": 0, "alert": 1, "crit": 2, "err": 3,
+	"warning": 4, "notice": 5, "info": 6, "debug": 7,
+}
+
+// openNetSink dials the address of the URL. The query parameters
+// timeout, write_timeout, facility and severity are supported. With a
+// write_timeout, writes are wrapped in a TimeoutWriter. With a facility,
+// each write is prefixed with a syslog priority.
+func openNetSink(u *url.URL) (io.Writer, error) {
+	q := u.Query()
+	timeout := 5 * time.Second
+	if v := q.Get("timeout"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		timeout = d
+	}
+	var writeTimeout time.Duration
+	if v := q.Get("write_timeout"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		writeTimeout = d
+	}
+	addr := u.Host
+	if u.Scheme == "unix" {
+		addr = u.Path
+	}
+	c, err := net.DialTimeout(u.Scheme, addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	var conn io.WriteCloser = c
+	if writeTimeout > 0 {
+		conn = &timeoutConn{NewTimeoutWriter(c, writeTimeout), c}
+	}
+	if !q.Has("facility") {
+		return conn, nil
+	}
+
+	facility, ok := syslogFacilities[q.Get("facility")]
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("invalid facility '%s'", q.Get("facility"))
+	}
+	severity := syslogSeverities["info"]
+	if v := q.Get("severity"); v != "" {
+		if severity, ok = syslogSeverities[v]; !ok {
+			conn.Close()
+			return nil, fmt.Errorf("invalid severity '%s'", v)
+		}
+	}
+	return &syslogWriter{
+		w:      conn,
+		prefix: []byte("<" + strconv.Itoa(facility*8+severity) + ">"),
+	}, nil
+}
+
+// timeoutConn is a connection whose writes time out.
+type timeoutConn struct {
+	*TimeoutWriter
+	io.Closer
+}
+
+type syslogWriter struct {
+	w      io.Writer
+	prefix []byte
+}
+
+func (w *syslogWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(append(w.prefix[:len(w.prefix):len(w.prefix)], p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection.
+func (w *syslogWriter) Close() error {
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// OpenRoute adds a Route for a tag to an output opened with OpenSink and
+// encoded with the registered encoder, e.g.
+// OpenRoute("security", "tcp://siem:514", "cef"). An empty encoder uses
+// the Format of the Logger.
+func OpenRoute(tag string, sinkURL string, encoder string) error {
+	w, err := OpenSink(sinkURL)
+	if err != nil {
+		return err
+	}
+	r := Route{Tag: tag, Output: w}
+	if encoder != "" {
+		if r.Encoder, err = NewEncoder(encoder, nil); err != nil {
+			closeSink(w)
+			return err
+		}
+	}
+	return AddRoute(r)
+}
+
+// closeSink closes an output opened with OpenSink, unless it is Stdout or
+// Stderr.
+func closeSink(w io.Writer) error {
+	if w == os.Stdout || w == os.Stderr {
+		return nil
+	}
+	if c, ok := w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
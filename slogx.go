@@ -1,14 +1,14 @@
 package slogx
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
-	"regexp"
-	"runtime"
+	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,199 +23,718 @@ const (
 	DEBUG
 )
 
-var levelToString = map[Level]string{
-	NONE:    "NONE",
-	FATAL:   "FATAL",
-	ERROR:   "ERROR",
-	WARNING: "WARNING",
-	INFO:    "INFO",
-	DEBUG:   "DEBUG",
-}
+var levelNames = [...]string{"NONE", "FATAL", "ERROR", "WARNING", "INFO", "DEBUG"}
 
 func (l Level) String() string {
-	return levelToString[l]
+	if !l.valid() {
+		return ""
+	}
+	return currentLabels().names[l]
+}
+
+// Set sets the Level from its string name, implementing flag.Value.
+func (l *Level) Set(s string) error {
+	level, err := ParseLevelE(s)
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// MarshalText returns the string name of the Level.
+func (l Level) MarshalText() ([]byte, error) {
+	if !l.valid() {
+		return nil, fmt.Errorf("slogx: invalid level %d", l)
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText sets the Level from its string name.
+func (l *Level) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+// MarshalJSON returns the string name of the Level as a JSON string.
+func (l Level) MarshalJSON() ([]byte, error) {
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the Level from a JSON string name or number.
+func (l *Level) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return l.Set(name)
+	}
+	var n uint
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("slogx: invalid level %s", data)
+	}
+	if !Level(n).valid() {
+		return fmt.Errorf("slogx: invalid level %d", n)
+	}
+	*l = Level(n)
+	return nil
+}
+
+func (l Level) valid() bool {
+	return l <= DEBUG
+}
+
+// levelLabels are the string names of the levels and the levels by their
+// upper-case names. They are replaced, not modified, when a label is set.
+type levelLabels struct {
+	names  [DEBUG + 1]string
+	levels map[string]Level
 }
 
-var stringToLevel = map[string]Level{
-	"NONE":    NONE,
-	"FATAL":   FATAL,
-	"ERROR":   ERROR,
-	"WARNING": WARNING,
-	"INFO":    INFO,
-	"DEBUG":   DEBUG,
+var defaultLabels = &levelLabels{
+	names: levelNames,
+	levels: map[string]Level{
+		"NONE":    NONE,
+		"FATAL":   FATAL,
+		"ERROR":   ERROR,
+		"WARNING": WARNING,
+		"INFO":    INFO,
+		"DEBUG":   DEBUG,
+	},
+}
+
+var (
+	labels      atomic.Value
+	labelsMutex sync.Mutex
+)
+
+// currentLabels returns the current levelLabels.
+func currentLabels() *levelLabels {
+	if ll, ok := labels.Load().(*levelLabels); ok {
+		return ll
+	}
+	return defaultLabels
 }
 
-var loggers = make(map[string]*Logger)
+// SetLevelLabel sets the string name of the Level used by formats,
+// encoders and ParseLevel, e.g. "WARN" for WARNING. The original name is
+// still accepted by ParseLevel.
+func SetLevelLabel(level Level, label string) error {
+	if !level.valid() {
+		return fmt.Errorf("slogx: invalid level %d", level)
+	}
+	if label == "" {
+		return fmt.Errorf("slogx: invalid label '%s'", label)
+	}
+	labelsMutex.Lock()
+	defer labelsMutex.Unlock()
+	current := currentLabels()
+	if l, ok := current.levels[strings.ToUpper(label)]; ok && l != level {
+		return fmt.Errorf("slogx: label '%s' is used by %s", label, current.names[l])
+	}
+	next := &levelLabels{
+		names:  current.names,
+		levels: make(map[string]Level, len(current.levels)+1),
+	}
+	for name, l := range current.levels {
+		next.levels[name] = l
+	}
+	if old := strings.ToUpper(next.names[level]); old != levelNames[level] {
+		delete(next.levels, old)
+	}
+	next.names[level] = label
+	next.levels[strings.ToUpper(label)] = level
+	labels.Store(next)
+	return nil
+}
+
+const (
+	// DefaultFormat is the Format of a new Logger.
+	DefaultFormat = "${time} ${level} ${file}:${line} ${name}: ${message}${fields}"
+	// DefaultTimeFormat is the TimeFormat of a new Logger.
+	DefaultTimeFormat = "2006-01-02 15:04:05"
+
+	defaultFormat = "%[1]s %[2]s %[3]s:%[4]d %[5]s: %[6]s%[7]s"
+)
+
+var (
+	loggers      = make(map[string]*Logger)
+	loggersMutex sync.Mutex
+)
 
+// Logger writes entries of a name. Its configuration is read with the
+// getters and changed with the setters, which replace an immutable snapshot
+// that logging reads without locking.
 type Logger struct {
-	Name       string
-	Level      Level
-	Format     string
+	// Deprecated: Use GetName. Name only reflects the name of the Logger.
+	Name string
+	// Deprecated: Use GetLevel and SetLevel. Level only reflects the
+	// configuration of a Logger returned by NewLogger.
+	Level Level
+	// Deprecated: Use GetFormat and SetFormat. Format only reflects the
+	// parsed configuration of a Logger returned by NewLogger.
+	Format string
+	// Deprecated: Use GetTimeFormat and SetTimeFormat. TimeFormat only
+	// reflects the configuration of a Logger returned by NewLogger.
 	TimeFormat string
-	Output     io.Writer
-	Mutex      sync.Mutex
+	// Deprecated: Use GetOutput and SetOutput. Output only reflects the
+	// configuration of a Logger returned by NewLogger.
+	Output io.Writer
+	// Deprecated: Use GetEncoder and SetEncoder. Encoder only reflects the
+	// configuration of a Logger returned by NewLogger.
+	Encoder Encoder
+	// Deprecated: Use GetHooks and AddHook. Hooks only reflects the
+	// configuration of a Logger returned by NewLogger.
+	Hooks []Hook
+	Mutex sync.Mutex
+	cfg   atomic.Value
+	stats *loggerStats
+}
+
+// config is an immutable snapshot of the configuration of a Logger.
+type config struct {
+	name        string
+	level       Level
+	pattern     string
+	format      string
+	timeFormat  string
+	output      io.Writer
+	encoder     Encoder
+	hooks       []Hook
+	sinks       []outputSink
+	scopes      []string
+	scope       string
+	muted       bool
+	crashDump   bool
+	crashFile   string
+	exitCode    int
+	buffer      *Buffer
+	maxMessage  int
+	maxField    int
+	allowed     map[string]bool
+	denied      map[string]bool
+	sampler     *sampler
+	snippet     int
+	nameWidth   int
+	clock       func() time.Time
+	sequence    bool
+	precision   time.Duration
+	tags        []string
+	tenant      string
+	repanic     bool
+	http        httpConfig
+	development bool
+	schema      *Schema
+	latency     *latencyStats
+	sites       *siteStats
+}
+
+// config returns the current configuration of the Logger. A Logger that
+// was not created by NewLogger is configured by its exported fields, with
+// an unparsed Format parsed or replaced by the DefaultFormat.
+func (l *Logger) config() *config {
+	if c, ok := l.cfg.Load().(*config); ok {
+		return c
+	}
+	c := &config{
+		name:       l.Name,
+		level:      l.Level,
+		pattern:    l.Format,
+		format:     l.Format,
+		timeFormat: l.TimeFormat,
+		output:     l.Output,
+		encoder:    l.Encoder,
+		hooks:      l.Hooks,
+		exitCode:   1,
+	}
+	if strings.Contains(c.format, "${") {
+		format, err := parseFormat(c.format)
+		if err != nil {
+			c.pattern, format = DefaultFormat, defaultFormat
+		}
+		c.format = format
+	}
+	return c
+}
+
+// update applies fn to a copy of the configuration of the Logger and
+// replaces the configuration with it.
+func (l *Logger) update(fn func(c *config)) {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+	c := *l.config()
+	fn(&c)
+	l.store(&c)
+}
+
+// store replaces the configuration of the Logger and its exported fields.
+func (l *Logger) store(c *config) {
+	l.cfg.Store(c)
+	l.Name = c.name
+	l.Level = c.level
+	l.Format = c.format
+	l.TimeFormat = c.timeFormat
+	l.Output = c.output
+	l.Encoder = c.encoder
+	l.Hooks = c.hooks
+}
+
+// Hook receives every Entry written by a Logger.
+type Hook interface {
+	Fire(e Entry) error
 }
 
 // NewLogger returns a new Logger.
 func NewLogger(name string) *Logger {
-	logger := &Logger{
-		Name:       name,
-		Level:      INFO,
-		Format:     "%[1]s %[2]s %[3]s:%[4]d %[5]s: %[6]s",
-		TimeFormat: "2006-01-02 15:04:05",
-		Output:     os.Stdout,
-	}
-	loggers[logger.Name] = logger
+	logger := newLogger(name)
+	loggersMutex.Lock()
+	defer loggersMutex.Unlock()
+	loggers[name] = logger
 	return logger
 }
 
+// newLogger returns a new unregistered Logger.
+func newLogger(name string) *Logger {
+	l := &Logger{stats: &loggerStats{}}
+	l.store(&config{
+		name:       name,
+		level:      INFO,
+		pattern:    DefaultFormat,
+		format:     defaultFormat,
+		timeFormat: DefaultTimeFormat,
+		output:     os.Stdout,
+		exitCode:   1,
+	})
+	return l
+}
+
 // GetLogger returns a Logger by its name.
 func GetLogger(name string) *Logger {
+	loggersMutex.Lock()
+	defer loggersMutex.Unlock()
 	return loggers[name]
 }
 
-// ParseLevel returns a logging Level based on its string name.
+var levelAliases = map[string]Level{
+	"CRITICAL": FATAL,
+	"ERR":      ERROR,
+	"WARN":     WARNING,
+	"TRACE":    DEBUG,
+}
+
+// Mute disables all registered and tenant loggers whose name matches the
+// pattern, regardless of their Level. The pattern syntax is that of
+// path.Match.
+func Mute(pattern string) error {
+	return setMuted(pattern, true)
+}
+
+// Unmute enables all registered and tenant loggers whose name matches the
+// pattern.
+func Unmute(pattern string) error {
+	return setMuted(pattern, false)
+}
+
+func setMuted(pattern string, muted bool) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("slogx: invalid pattern '%s'", pattern)
+	}
+	for _, logger := range allLoggers() {
+		if ok, _ := path.Match(pattern, logger.config().name); ok {
+			logger.update(func(c *config) {
+				c.muted = muted
+			})
+		}
+	}
+	return nil
+}
+
+// ParseLevel returns a logging Level based on its string name or alias.
+// Unknown names return NONE.
 func ParseLevel(level string) Level {
-	return stringToLevel[strings.ToUpper(level)]
+	l, _ := ParseLevelE(level)
+	return l
+}
+
+// ParseLevelE returns a logging Level based on its string name or alias,
+// or an error if the name is unknown.
+func ParseLevelE(level string) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(level))
+	if l, ok := currentLabels().levels[name]; ok {
+		return l, nil
+	}
+	if l, ok := levelAliases[name]; ok {
+		return l, nil
+	}
+	return NONE, fmt.Errorf("slogx: invalid level '%s'", level)
+}
+
+// globalLevel caps the Level of all loggers.
+var globalLevel = int32(DEBUG)
+
+// SetGlobalLevel caps the Level of all loggers, including loggers created
+// later, e.g. to reduce noise during an incident. Loggers with a less
+// verbose Level keep it. SetGlobalLevel(DEBUG) removes the cap and
+// SetGlobalLevel(NONE) silences all loggers.
+func SetGlobalLevel(level Level) {
+	atomic.StoreInt32(&globalLevel, int32(level))
+}
+
+// GetGlobalLevel returns the Level all loggers are capped at.
+func GetGlobalLevel() Level {
+	return Level(atomic.LoadInt32(&globalLevel))
 }
 
 // SetLevel sets the logging Level for the Logger.
 func (l *Logger) SetLevel(level Level) {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
-	l.Level = level
+	l.update(func(c *config) {
+		c.level = level
+	})
+}
+
+// ElevateLevel sets the logging Level for the Logger for the duration,
+// after which the previous Level is restored. Calling cancel restores it
+// early. The previous Level is not restored if the Level was changed in
+// the meantime.
+func (l *Logger) ElevateLevel(level Level, duration time.Duration) (cancel func()) {
+	var previous Level
+	l.update(func(c *config) {
+		previous = c.level
+		c.level = level
+	})
+
+	var once sync.Once
+	restore := func() {
+		once.Do(func() {
+			l.update(func(c *config) {
+				if c.level == level {
+					c.level = previous
+				}
+			})
+		})
+	}
+	timer := time.AfterFunc(duration, restore)
+	return func() {
+		timer.Stop()
+		restore()
+	}
 }
 
 // GetLevel returns the current logging Level for the Logger.
 func (l *Logger) GetLevel() Level {
-	return l.Level
+	return l.config().level
+}
+
+// GetName returns the name of the Logger.
+func (l *Logger) GetName() string {
+	return l.config().name
+}
+
+// GetFormat returns the Format of the Logger with its placeholders.
+func (l *Logger) GetFormat() string {
+	return l.config().pattern
+}
+
+// GetTimeFormat returns the time layout of the Logger.
+func (l *Logger) GetTimeFormat() string {
+	return l.config().timeFormat
+}
+
+// GetOutput returns the output writer of the Logger.
+func (l *Logger) GetOutput() io.Writer {
+	return l.config().output
+}
+
+// GetEncoder returns the Encoder of the Logger, or nil if entries are
+// formatted with the Format.
+func (l *Logger) GetEncoder() Encoder {
+	return l.config().encoder
+}
+
+// GetHooks returns the Hooks of the Logger.
+func (l *Logger) GetHooks() []Hook {
+	return append([]Hook(nil), l.config().hooks...)
 }
 
 // SetFormat sets the Format for the Logger.
 func (l *Logger) SetFormat(format string) error {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
 	parsed, err := parseFormat(format)
 	if err != nil {
 		return err
 	}
-	l.Format = parsed
+	l.update(func(c *config) {
+		c.pattern = format
+		c.format = parsed
+	})
 	return nil
 }
 
 // SetTimeFormat sets the TimeFormat for the Logger.
 func (l *Logger) SetTimeFormat(layout string) {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
-	l.TimeFormat = layout
+	l.update(func(c *config) {
+		c.timeFormat = layout
+	})
+}
+
+// SetEncoder sets the Encoder for the Logger. If an Encoder is set, it is
+// used instead of the Format and TimeFormat.
+func (l *Logger) SetEncoder(encoder Encoder) {
+	l.update(func(c *config) {
+		c.encoder = encoder
+	})
 }
 
 // SetOutput sets the Output for the Logger.
 func (l *Logger) SetOutput(writer io.Writer) {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
-	l.Output = writer
+	l.update(func(c *config) {
+		c.output = writer
+	})
 }
 
-var formatPlaceholders = map[string]string{
-	"${time}":    "%[1]s",
-	"${level}":   "%[2]s",
-	"${file}":    "%[3]s",
-	"${line}":    "%[4]d",
-	"${name}":    "%[5]s",
-	"${message}": "%[6]s",
+// outputSink is an additional output of a Logger with its own Encoder.
+type outputSink struct {
+	output  io.Writer
+	encoder Encoder
+	levels  LevelMap
 }
 
-func parseFormat(format string) (string, error) {
-	format = strings.Replace(format, "%", "%%", -1)
-	re := regexp.MustCompile("\\${([a-zA-Z]+)}")
-	m := re.FindAllStringSubmatch(format, -1)
-	if m != nil {
-		for _, v := range m {
-			placeholder := formatPlaceholders[v[0]]
-			if placeholder == "" {
-				return "", fmt.Errorf("slogx: invalid verb '%s'", v[0])
-			}
-			format = strings.Replace(format, v[0], placeholder, -1)
-		}
-	} else {
-		return "", fmt.Errorf("slogx: invalid format '%s'", format)
-	}
-	return format, nil
+// AddOutput adds an output the Logger writes entries to, encoded with the
+// Encoder, or the Format of the Logger if it is nil. This writes one
+// Logger in several representations, e.g. text to the console and JSON to
+// a file.
+func (l *Logger) AddOutput(writer io.Writer, encoder Encoder) {
+	l.AddMappedOutput(writer, encoder, nil)
 }
 
-func (l *Logger) write(log string) {
-	_, err := fmt.Fprintln(l.Output, log)
-	if err != nil {
-		fmt.Println(fmt.Errorf("slogx: %v", err))
-	}
+// clone returns an unregistered copy of the Logger.
+func (l *Logger) clone() *Logger {
+	l.Mutex.Lock()
+	defer l.Mutex.Unlock()
+	c := &Logger{stats: &loggerStats{}}
+	c.store(l.config())
+	return c
 }
 
-// Log logs a message at the specified Level.
-func (l *Logger) Log(level Level, args ...interface{}) {
-	if l.Level < level || level == NONE {
-		return
-	}
-	msg := fmt.Sprint(args...)
-	ts := time.Now().Format(l.TimeFormat)
-	_, fl, ln, _ := runtime.Caller(2)
-	log := fmt.Sprintf(l.Format, ts, level.String(), filepath.Base(fl), ln, l.Name, msg)
-	l.write(log)
+// To returns a copy of the Logger that also writes to the writers, for
+// sending selected entries to an additional output. The copy is not
+// registered.
+func (l *Logger) To(writers ...io.Writer) *Logger {
+	c := l.clone()
+	c.update(func(cfg *config) {
+		cfg.output = io.MultiWriter(append([]io.Writer{cfg.output}, writers...)...)
+	})
+	return c
 }
 
-// Logf logs a message at the specified Level with formatting.
-func (l *Logger) Logf(level Level, format string, args ...interface{}) {
-	l.Log(level, fmt.Sprintf(format, args...))
+// PushScope pushes a scope onto the nested diagnostic context of the Logger.
+// The scopes are joined with slashes and available as ${scope}.
+func (l *Logger) PushScope(scope string) {
+	l.update(func(c *config) {
+		c.scopes = append(append([]string(nil), c.scopes...), scope)
+		c.scope = strings.Join(c.scopes, "/")
+	})
 }
 
-// Fatal logs a message at FATAL Level and exits.
-func (l *Logger) Fatal(args ...interface{}) {
-	l.Log(FATAL, fmt.Sprint(args...))
-	os.Exit(1)
+// PopScope removes the most recently pushed scope.
+func (l *Logger) PopScope() {
+	l.update(func(c *config) {
+		if len(c.scopes) == 0 {
+			return
+		}
+		c.scopes = c.scopes[:len(c.scopes)-1]
+		c.scope = strings.Join(c.scopes, "/")
+	})
 }
 
-// Fatalf logs a message at FATAL Level with formatting and exits.
-func (l *Logger) Fatalf(format string, args ...interface{}) {
-	l.Log(FATAL, fmt.Sprintf(format, args...))
-	os.Exit(1)
+// AddHook adds a Hook to the Logger.
+func (l *Logger) AddHook(hook Hook) {
+	l.update(func(c *config) {
+		c.hooks = append(append([]Hook(nil), c.hooks...), hook)
+	})
 }
 
-// Error logs a message at ERROR Level.
-func (l *Logger) Error(args ...interface{}) {
-	l.Log(ERROR, fmt.Sprint(args...))
+var formatPlaceholders = map[string]string{
+	"${time}":      "%[1]s",
+	"${level}":     "%[2]s",
+	"${file}":      "%[3]s",
+	"${line}":      "%[4]d",
+	"${name}":      "%[5]s",
+	"${message}":   "%[6]s",
+	"${fields}":    "%[7]s",
+	"${scope}":     "%[8]s",
+	"${version}":   "%[9]s",
+	"${commit}":    "%[10]s",
+	"${buildtime}": "%[11]s",
+	"${symbol}":    "%[12]s",
+	"${shortname}": "%[13]s",
+	"${namepath}":  "%[14]s",
 }
 
-// Errorf logs a message at ERROR Level with formatting.
-func (l *Logger) Errorf(format string, args ...interface{}) {
-	l.Log(ERROR, fmt.Sprintf(format, args...))
+func parseFormat(format string) (string, error) {
+	tokens, err := scanFormat(format)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, t := range tokens {
+		if t.verb != "" {
+			b.WriteString(formatPlaceholders[t.verb])
+		} else {
+			b.WriteString(strings.Replace(t.literal, "%", "%%", -1))
+		}
+	}
+	return b.String(), nil
 }
 
-// Warning logs a message at WARNING Level.
-func (l *Logger) Warning(args ...interface{}) {
-	l.Log(WARNING, fmt.Sprint(args...))
+// emit encodes the Entry with the Encoder, or the Format if it is nil, and
+// writes it to the writer of the named sink.
+func (l *Logger) emit(c *config, sink string, w io.Writer, enc Encoder, e Entry) {
+	stats := c.latency
+	start := stats.now()
+	var record []byte
+	var err error
+	if enc == nil {
+		e.Name = abbreviateName(e.Name, c.nameWidth)
+		record = []byte(formatEntry(c.format, withPrecision(c.timeFormat, c.precision), e))
+	} else {
+		record, err = enc.Encode(e)
+	}
+	stats.observeEncode(sink, start)
+	if err != nil {
+		l.stats.fail(err)
+		l.diagnose(ERROR, Fields{"error": err}, "encode failed")
+		return
+	}
+	start = stats.now()
+	l.write(w, enc, record)
+	stats.observeWrite(sink, start)
 }
 
-// Warningf logs a message at WARNING Level with formatting.
-func (l *Logger) Warningf(format string, args ...interface{}) {
-	l.Log(WARNING, fmt.Sprintf(format, args...))
+func (l *Logger) write(w io.Writer, enc Encoder, record []byte) {
+	if _, ok := enc.(binaryEncoder); !ok {
+		record = append(record, '\n')
+	}
+	n, err := w.Write(record)
+	l.stats.wrote(n)
+	if err != nil {
+		l.stats.fail(err)
+		l.diagnose(ERROR, Fields{"error": err}, "write failed")
+	}
 }
 
-// Info logs a message at INFO Level.
-func (l *Logger) Info(args ...interface{}) {
-	l.Log(INFO, fmt.Sprint(args...))
+// Entry is a single log entry.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	File    string
+	Line    int
+	Name    string
+	Message string
+	Fields  Fields
+	Scope   string
 }
 
-// Infof logs a message at INFO Level with formatting.
-func (l *Logger) Infof(format string, args ...interface{}) {
-	l.Log(INFO, fmt.Sprintf(format, args...))
+func formatEntry(format string, layout string, e Entry) string {
+	ts := e.Time.Format(layout)
+	return fmt.Sprintf(format, ts, e.Level.String(), e.File, e.Line, e.Name, e.Message, e.Fields.String(), e.Scope,
+		buildVersion, buildCommit, buildTime, e.Level.Symbol(), shortName(e.Name), namePath(e.Name))
 }
 
-// Debug logs a message at DEBUG Level.
-func (l *Logger) Debug(args ...interface{}) {
-	l.Log(DEBUG, fmt.Sprint(args...))
+func (l *Logger) output(e Entry) {
+	if disabled {
+		return
+	}
+	c := l.config()
+	if c.sequence {
+		stamp(&e)
+	}
+	if len(c.tags) > 0 {
+		addField(&e, "tags", c.tags)
+	}
+	if c.tenant != "" {
+		addField(&e, "tenant", c.tenant)
+	}
+	if list := classify(e); list != nil {
+		addField(&e, "classes", list)
+	}
+	if c.development && c.schema != nil {
+		l.validate(c.schema, e)
+	}
+	if c.sampler != nil && !c.sampler.sample(e) {
+		return
+	}
+	if c.buffer != nil && c.buffer.hold(e) {
+		return
+	}
+	if c.allowed != nil || c.denied != nil {
+		c.filterFields(&e)
+	}
+	if c.maxMessage > 0 || c.maxField > 0 {
+		c.truncate(&e)
+	}
+	l.stats.count(e)
+	c.sites.count(e)
+	l.emit(c, "output", c.output, c.encoder, e)
+	for i, s := range c.sinks {
+		se := e
+		var ok bool
+		if se.Level, ok = s.levels.translate(e.Level); ok {
+			l.emit(c, c.latency.sinkName("sink", i), s.output, s.encoder, se)
+		}
+	}
+	if rs, ok := routes.Load().([]Route); ok {
+		for i, r := range rs {
+			if r.match(e) {
+				l.emit(c, c.latency.sinkName("route", i), r.Output, r.Encoder, e)
+			}
+		}
+	}
+	stats := c.latency
+	for _, hook := range c.hooks {
+		start := stats.now()
+		err := hook.Fire(e)
+		stats.observeHook(hook, start)
+		if err != nil {
+			l.stats.fail(err)
+			l.diagnose(ERROR, Fields{"error": err}, "hook failed")
+		}
+	}
+}
+
+func (l *Logger) enabled(level Level) bool {
+	if disabled {
+		return false
+	}
+	c := l.config()
+	return !c.muted && c.level >= level && GetGlobalLevel() >= level && level != NONE
+}
+
+func (l *Logger) log(level Level, fields Fields, msg string) {
+	if !l.enabled(level) {
+		return
+	}
+	c := l.config()
+	site := caller(2)
+	if c.snippet > 0 && level <= ERROR {
+		c.addSnippet(site.path, site.line, &msg, &fields)
+	}
+	l.output(Entry{
+		Time:    c.now(),
+		Level:   level,
+		File:    site.file,
+		Line:    site.line,
+		Name:    c.name,
+		Message: msg,
+		Fields:  fields,
+		Scope:   c.scope,
+	})
 }
 
-// Debugf logs a message at DEBUG Level with formatting.
-func (l *Logger) Debugf(format string, args ...interface{}) {
-	l.Log(DEBUG, fmt.Sprintf(format, args...))
+// SetDevelopment sets whether the Logger is in development mode, in which
+// DPanic panics after logging and entries are validated against the Schema.
+func (l *Logger) SetDevelopment(enabled bool) {
+	l.update(func(c *config) {
+		c.development = enabled
+	})
 }
@@ -0,0 +1,146 @@
+package slogx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats are the counters of a Logger.
+type Stats struct {
+	Entries       map[Level]uint64
+	Bytes         uint64
+	LastEntry     time.Time
+	LastError     error
+	LastErrorTime time.Time
+	CallSites     []CallSite
+}
+
+// loggerStats are the counters of a Logger.
+type loggerStats struct {
+	levels        [DEBUG + 1]uint64
+	bytes         uint64
+	mutex         sync.Mutex
+	lastEntry     time.Time
+	lastError     error
+	lastErrorTime time.Time
+}
+
+// count records a written Entry if s is not nil.
+func (s *loggerStats) count(e Entry) {
+	if s == nil || !e.Level.valid() {
+		return
+	}
+	atomic.AddUint64(&s.levels[e.Level], 1)
+	s.mutex.Lock()
+	s.lastEntry = e.Time
+	s.mutex.Unlock()
+}
+
+// wrote records n bytes written if s is not nil.
+func (s *loggerStats) wrote(n int) {
+	if s == nil {
+		return
+	}
+	atomic.AddUint64(&s.bytes, uint64(n))
+}
+
+// fail records an error of encoding, writing or firing a Hook if s is not
+// nil.
+func (s *loggerStats) fail(err error) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.lastError = err
+	s.lastErrorTime = time.Now()
+	s.mutex.Unlock()
+}
+
+func (s *loggerStats) entries() uint64 {
+	var n uint64
+	for i := range s.levels {
+		n += atomic.LoadUint64(&s.levels[i])
+	}
+	return n
+}
+
+// Stats returns the number of entries written by Level, the number of bytes
+// written to the outputs, the time of the last Entry and the last error of
+// encoding, writing or firing a Hook. With call site tracking, it returns
+// the call sites sorted by the number of entries written.
+func (l *Logger) Stats() Stats {
+	stats := Stats{Entries: make(map[Level]uint64)}
+	s := l.stats
+	if s == nil {
+		return stats
+	}
+	for level := FATAL; level <= DEBUG; level++ {
+		stats.Entries[level] = atomic.LoadUint64(&s.levels[level])
+	}
+	stats.Bytes = atomic.LoadUint64(&s.bytes)
+	s.mutex.Lock()
+	stats.LastEntry = s.lastEntry
+	stats.LastError = s.lastError
+	stats.LastErrorTime = s.lastErrorTime
+	s.mutex.Unlock()
+	if sites := l.config().sites; sites != nil {
+		stats.CallSites = sites.sites()
+	}
+	return stats
+}
+
+// LoggerInfo describes a registered Logger.
+type LoggerInfo struct {
+	Name    string   `json:"name"`
+	Tenant  string   `json:"tenant,omitempty"`
+	Level   Level    `json:"level"`
+	Outputs []string `json:"outputs"`
+	Entries uint64   `json:"entries"`
+}
+
+// Snapshot returns a LoggerInfo of every registered and tenant Logger,
+// sorted by tenant and name.
+func Snapshot() []LoggerInfo {
+	list := allLoggers()
+	infos := make([]LoggerInfo, 0, len(list))
+	for _, l := range list {
+		infos = append(infos, l.info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Tenant != infos[j].Tenant {
+			return infos[i].Tenant < infos[j].Tenant
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+func (l *Logger) info() LoggerInfo {
+	c := l.config()
+	info := LoggerInfo{
+		Name:    c.name,
+		Tenant:  c.tenant,
+		Level:   c.level,
+		Outputs: []string{outputName(c.output)},
+	}
+	for _, s := range c.sinks {
+		info.Outputs = append(info.Outputs, outputName(s.output))
+	}
+	if l.stats != nil {
+		info.Entries = l.stats.entries()
+	}
+	return info
+}
+
+// outputName returns the file name of an output, or its type.
+func outputName(w io.Writer) string {
+	if f, ok := w.(*os.File); ok {
+		return f.Name()
+	}
+	return fmt.Sprintf("%T", w)
+}
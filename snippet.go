@@ -0,0 +1,81 @@
+package slogx
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	sourceFiles      = make(map[string][]string)
+	sourceFilesMutex sync.Mutex
+)
+
+// SetSourceSnippet adds the n lines of source code before and after the
+// log statement to ERROR and FATAL entries, read from the source file on
+// disk. With a JSONEncoder the snippet is added as the field source,
+// otherwise it is appended to the message. Zero disables snippets. This is
+// meant for development, where the source tree is available.
+func (l *Logger) SetSourceSnippet(n int) {
+	l.update(func(c *config) {
+		c.snippet = n
+	})
+}
+
+// addSnippet adds the source snippet around line of file to the message or
+// Fields.
+func (c *config) addSnippet(file string, line int, msg *string, fields *Fields) {
+	snippet := sourceSnippet(file, line, c.snippet)
+	if snippet == "" {
+		return
+	}
+	if _, ok := c.encoder.(*JSONEncoder); ok {
+		f := Fields{}
+		for k, v := range *fields {
+			f[k] = v
+		}
+		f["source"] = snippet
+		*fields = f
+		return
+	}
+	*msg += "\n" + snippet
+}
+
+// sourceSnippet returns the n lines before and after line of file with
+// line numbers, marking the line itself.
+func sourceSnippet(file string, line int, n int) string {
+	sourceFilesMutex.Lock()
+	lines, ok := sourceFiles[file]
+	if !ok {
+		if data, err := os.ReadFile(file); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		sourceFiles[file] = lines
+	}
+	sourceFilesMutex.Unlock()
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	first, last := line-n, line+n
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+	width := len(fmt.Sprint(last))
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s %*d | %s", marker, width, i, lines[i-1])
+		if i < last {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package slogx
+
+import (
+	"os"
+	"runtime"
+	"time"
+)
+
+var startTime = time.Now()
+
+// every calls fn every interval until the returned function is called. A
+// non-positive interval never calls fn.
+func every(interval time.Duration, fn func()) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fn()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// ReportRuntimeStats logs heap, garbage collection, goroutine and open file
+// statistics at the specified Level every interval until stop is called.
+// A non-positive interval disables it.
+func (l *Logger) ReportRuntimeStats(level Level, interval time.Duration) (stop func()) {
+	return every(interval, func() {
+		l.logRuntimeStats(level)
+	})
+}
+
+// Heartbeat logs an "alive" message with the process uptime and the number
+// of heartbeats at the specified Level every interval until stop is called.
+// If counters is not nil, the Fields it returns are added to each message.
+// A non-positive interval disables it.
+func (l *Logger) Heartbeat(level Level, interval time.Duration, counters func() Fields) (stop func()) {
+	beats := 0
+	return every(interval, func() {
+		beats++
+		l.logHeartbeat(level, beats, counters)
+	})
+}
+
+func (l *Logger) logHeartbeat(level Level, beats int, counters func() Fields) {
+	if !l.enabled(level) {
+		return
+	}
+	fields := Fields{}
+	if counters != nil {
+		for k, v := range counters() {
+			fields[k] = v
+		}
+	}
+	fields["uptime"] = time.Since(startTime).Round(time.Second)
+	fields["heartbeat"] = beats
+	l.log(level, fields, "alive")
+}
+
+func (l *Logger) logRuntimeStats(level Level) {
+	if !l.enabled(level) {
+		return
+	}
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	fields := Fields{
+		"heap_alloc":     m.HeapAlloc,
+		"heap_inuse":     m.HeapInuse,
+		"heap_objects":   m.HeapObjects,
+		"gc_count":       m.NumGC,
+		"gc_pause_total": time.Duration(m.PauseTotalNs),
+		"goroutines":     runtime.NumGoroutine(),
+	}
+	if m.NumGC > 0 {
+		fields["gc_pause_last"] = time.Duration(m.PauseNs[(m.NumGC+255)%256])
+	}
+	if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+		fields["open_fds"] = len(fds)
+	}
+	l.log(level, fields, "runtime stats")
+}
@@ -0,0 +1,78 @@
+package slogx
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// clearLine moves the cursor to the start of the line and erases it.
+const clearLine = "\r\033[K"
+
+// StatusLine is an io.Writer for terminals that keeps a status line below
+// the output. Lines written to it are printed above the status line, which
+// is set by writing to the writer returned by Status.
+type StatusLine struct {
+	Output io.Writer
+	Mutex  sync.Mutex
+	status []byte
+}
+
+// NewStatusLine returns a new StatusLine writing to the terminal w.
+func NewStatusLine(w io.Writer) *StatusLine {
+	return &StatusLine{
+		Output: w,
+	}
+}
+
+// Write prints p above the status line.
+func (s *StatusLine) Write(p []byte) (int, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	var b bytes.Buffer
+	if len(s.status) > 0 {
+		b.WriteString(clearLine)
+	}
+	b.Write(p)
+	b.Write(s.status)
+	if _, err := s.Output.Write(b.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Status returns an io.Writer that replaces the status line with the last
+// line written to it.
+func (s *StatusLine) Status() io.Writer {
+	return statusWriter{s}
+}
+
+// Clear removes the status line.
+func (s *StatusLine) Clear() error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	if len(s.status) == 0 {
+		return nil
+	}
+	s.status = nil
+	_, err := io.WriteString(s.Output, clearLine)
+	return err
+}
+
+type statusWriter struct {
+	s *StatusLine
+}
+
+func (w statusWriter) Write(p []byte) (int, error) {
+	status := bytes.TrimRight(p, "\r\n")
+	if i := bytes.LastIndexByte(status, '\n'); i >= 0 {
+		status = status[i+1:]
+	}
+	w.s.Mutex.Lock()
+	defer w.s.Mutex.Unlock()
+	w.s.status = append(w.s.status[:0], status...)
+	if _, err := io.WriteString(w.s.Output, clearLine+string(w.s.status)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,142 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// StreamKeepAlive is how often a StreamHook sends a comment to idle
+// clients, so proxies do not close their connections.
+var StreamKeepAlive = 30 * time.Second
+
+// StreamHook is a Hook and http.Handler streaming entries to clients as
+// server-sent events, for a live tail view in admin UIs. Clients filter the
+// entries with the query parameters level, the least severe Level
+// streamed, and logger, a path.Match pattern of logger names. Entries are
+// encoded with the Encoder, a JSONEncoder by default. Entries are dropped
+// for clients too slow to keep up.
+type StreamHook struct {
+	Encoder Encoder
+	Mutex   sync.Mutex
+	clients map[*streamClient]bool
+}
+
+type streamClient struct {
+	level   Level
+	pattern string
+	events  chan []byte
+}
+
+// NewStreamHook returns a new StreamHook.
+func NewStreamHook() *StreamHook {
+	return &StreamHook{
+		Encoder: NewJSONEncoder(),
+		clients: make(map[*streamClient]bool),
+	}
+}
+
+// Fire sends the Entry to the clients whose filters it matches.
+func (h *StreamHook) Fire(e Entry) error {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+	var event []byte
+	for c := range h.clients {
+		if e.Level > c.level {
+			continue
+		}
+		if c.pattern != "" {
+			if ok, _ := path.Match(c.pattern, e.Name); !ok {
+				continue
+			}
+		}
+		if event == nil {
+			record, err := h.Encoder.Encode(e)
+			if err != nil {
+				return err
+			}
+			event = sseEvent(record)
+		}
+		select {
+		case c.events <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// sseEvent returns the record as the data of an event.
+func sseEvent(record []byte) []byte {
+	var b bytes.Buffer
+	for _, line := range bytes.Split(bytes.TrimSuffix(record, []byte("\n")), []byte("\n")) {
+		b.WriteString("data: ")
+		b.Write(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.Bytes()
+}
+
+// ServeHTTP streams the entries matching the filters of the request until
+// the client disconnects.
+func (h *StreamHook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	c := &streamClient{
+		level:   DEBUG,
+		pattern: r.URL.Query().Get("logger"),
+		events:  make(chan []byte, 64),
+	}
+	if v := r.URL.Query().Get("level"); v != "" {
+		level, err := ParseLevelE(v)
+		if err != nil {
+			http.Error(w, "invalid level '"+v+"'", http.StatusBadRequest)
+			return
+		}
+		c.level = level
+	}
+	if _, err := path.Match(c.pattern, ""); err != nil {
+		http.Error(w, "invalid logger '"+c.pattern+"'", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	h.Mutex.Lock()
+	h.clients[c] = true
+	h.Mutex.Unlock()
+	defer func() {
+		h.Mutex.Lock()
+		delete(h.clients, c)
+		h.Mutex.Unlock()
+	}()
+
+	ticker := time.NewTicker(StreamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case event := <-c.events:
+			if _, err := w.Write(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
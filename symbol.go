@@ -0,0 +1,63 @@
+package slogx
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// UnicodeLevelSymbols are the symbols of the ${symbol} verb for terminals
+// supporting UTF-8.
+var UnicodeLevelSymbols = map[Level]string{
+	FATAL:   "✖",
+	ERROR:   "✖",
+	WARNING: "⚠",
+	INFO:    "ℹ",
+	DEBUG:   "•",
+}
+
+// ASCIILevelSymbols are the symbols of the ${symbol} verb for terminals
+// that only support ASCII.
+var ASCIILevelSymbols = map[Level]string{
+	FATAL:   "X",
+	ERROR:   "x",
+	WARNING: "!",
+	INFO:    "i",
+	DEBUG:   "-",
+}
+
+var (
+	levelToSymbol = defaultLevelSymbols()
+	symbolsMutex  sync.RWMutex
+)
+
+// defaultLevelSymbols returns UnicodeLevelSymbols if the locale uses
+// UTF-8 and ASCIILevelSymbols otherwise.
+func defaultLevelSymbols() map[Level]string {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			v = strings.ToUpper(v)
+			if strings.Contains(v, "UTF-8") || strings.Contains(v, "UTF8") {
+				return UnicodeLevelSymbols
+			}
+			return ASCIILevelSymbols
+		}
+	}
+	return ASCIILevelSymbols
+}
+
+// SetLevelSymbols sets the symbols of the ${symbol} verb. By default they
+// are UnicodeLevelSymbols if the locale uses UTF-8 and ASCIILevelSymbols
+// otherwise.
+func SetLevelSymbols(symbols map[Level]string) {
+	symbolsMutex.Lock()
+	defer symbolsMutex.Unlock()
+	levelToSymbol = symbols
+}
+
+// Symbol returns the symbol of the Level used by the ${symbol} verb.
+func (l Level) Symbol() string {
+	symbolsMutex.RLock()
+	defer symbolsMutex.RUnlock()
+	return levelToSymbol[l]
+}
@@ -0,0 +1,60 @@
+package slogx
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table logs rows as a table at the specified Level, e.g. for summaries of
+// CLI tools. The columns are aligned to their widest cell in a block below
+// the row count. With a JSONEncoder the rows are added as the field rows,
+// an array of objects keyed by the headers.
+func (l *Logger) Table(level Level, headers []string, rows [][]interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	msg := fmt.Sprintf("%d rows", len(rows))
+	if _, ok := l.config().encoder.(*JSONEncoder); ok {
+		objects := make([]*dumpObject, len(rows))
+		for i, row := range rows {
+			o := &dumpObject{keys: headers, values: make([]interface{}, len(headers))}
+			copy(o.values, row)
+			objects[i] = o
+		}
+		l.log(level, Fields{"rows": objects}, msg)
+		return
+	}
+
+	cells := make([][]string, 0, len(rows)+1)
+	cells = append(cells, headers)
+	for _, row := range rows {
+		line := make([]string, len(headers))
+		for i := range line {
+			if i < len(row) {
+				line[i] = fmt.Sprint(row[i])
+			}
+		}
+		cells = append(cells, line)
+	}
+	widths := make([]int, len(headers))
+	for _, line := range cells {
+		for i, cell := range line {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, line := range cells {
+		var row strings.Builder
+		for i, cell := range line {
+			row.WriteString(cell)
+			row.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+		b.WriteString("\n")
+		b.WriteString(strings.TrimRight(row.String(), " "))
+	}
+	l.log(level, nil, b.String())
+}
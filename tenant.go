@@ -0,0 +1,93 @@
+package slogx
+
+import (
+	"io"
+	"sync"
+)
+
+// TenantRegistry is a namespace of loggers of a tenant. Its loggers add the
+// field tenant to their entries and write to the outputs of the tenant, so
+// the logs of customers can be kept apart.
+type TenantRegistry struct {
+	ID      string
+	Mutex   sync.Mutex
+	loggers map[string]*Logger
+	sinks   []outputSink
+}
+
+var (
+	tenants      = make(map[string]*TenantRegistry)
+	tenantsMutex sync.Mutex
+)
+
+// Tenant returns the TenantRegistry of a tenant, creating it if needed.
+func Tenant(id string) *TenantRegistry {
+	tenantsMutex.Lock()
+	defer tenantsMutex.Unlock()
+	t, ok := tenants[id]
+	if !ok {
+		t = &TenantRegistry{
+			ID:      id,
+			loggers: make(map[string]*Logger),
+		}
+		tenants[id] = t
+	}
+	return t
+}
+
+// NewLogger returns a new Logger of the tenant. It is registered with the
+// tenant, not with GetLogger.
+func (t *TenantRegistry) NewLogger(name string) *Logger {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+	l := newLogger(name)
+	l.update(func(c *config) {
+		c.tenant = t.ID
+		c.sinks = append([]outputSink(nil), t.sinks...)
+	})
+	t.loggers[name] = l
+	return l
+}
+
+// allLoggers returns the registered loggers and the loggers of all tenants.
+func allLoggers() []*Logger {
+	loggersMutex.Lock()
+	list := make([]*Logger, 0, len(loggers))
+	for _, l := range loggers {
+		list = append(list, l)
+	}
+	loggersMutex.Unlock()
+
+	tenantsMutex.Lock()
+	registries := make([]*TenantRegistry, 0, len(tenants))
+	for _, t := range tenants {
+		registries = append(registries, t)
+	}
+	tenantsMutex.Unlock()
+	for _, t := range registries {
+		t.Mutex.Lock()
+		for _, l := range t.loggers {
+			list = append(list, l)
+		}
+		t.Mutex.Unlock()
+	}
+	return list
+}
+
+// GetLogger returns a Logger of the tenant by its name.
+func (t *TenantRegistry) GetLogger(name string) *Logger {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+	return t.loggers[name]
+}
+
+// AddOutput adds an output to all current and future loggers of the
+// tenant, like Logger.AddOutput.
+func (t *TenantRegistry) AddOutput(writer io.Writer, encoder Encoder) {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+	t.sinks = append(t.sinks, outputSink{output: writer, encoder: encoder})
+	for _, l := range t.loggers {
+		l.AddOutput(writer, encoder)
+	}
+}
@@ -0,0 +1,93 @@
+package slogx
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// TimeoutWriter is an io.Writer that stops waiting for its Output after
+// Timeout, so a hung connection or a full pipe cannot block logging. A
+// timed out write goes to the Fallback if set and is dropped otherwise, as
+// are all writes until the hung write returns. Outputs with a write
+// deadline, such as a net.Conn, use the deadline instead.
+type TimeoutWriter struct {
+	Output   io.Writer
+	Fallback io.Writer
+	Timeout  time.Duration
+	Mutex    sync.Mutex
+	pending  chan struct{}
+}
+
+type writeResult struct {
+	n   int
+	err error
+}
+
+type deadlineWriter interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// NewTimeoutWriter returns a new TimeoutWriter writing to w.
+func NewTimeoutWriter(w io.Writer, timeout time.Duration) *TimeoutWriter {
+	return &TimeoutWriter{
+		Output:  w,
+		Timeout: timeout,
+	}
+}
+
+// Write writes p to the Output, or to the Fallback if the write times out
+// or a previous write is still hung.
+func (w *TimeoutWriter) Write(p []byte) (int, error) {
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	if w.pending != nil {
+		select {
+		case <-w.pending:
+			w.pending = nil
+		default:
+			return w.fallback(p)
+		}
+	}
+
+	if d, ok := w.Output.(deadlineWriter); ok {
+		if err := d.SetWriteDeadline(time.Now().Add(w.Timeout)); err == nil {
+			n, err := w.Output.Write(p)
+			d.SetWriteDeadline(time.Time{})
+			if timeout, ok := err.(interface{ Timeout() bool }); ok && timeout.Timeout() {
+				diagnose(WARNING, Fields{"timeout": w.Timeout, "error": err}, "write timed out")
+				return w.fallback(p)
+			}
+			return n, err
+		}
+	}
+
+	buf := append([]byte(nil), p...)
+	done := make(chan writeResult, 1)
+	go func() {
+		n, err := w.Output.Write(buf)
+		done <- writeResult{n, err}
+	}()
+	timer := time.NewTimer(w.Timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.n, r.err
+	case <-timer.C:
+		pending := make(chan struct{})
+		w.pending = pending
+		go func() {
+			<-done
+			close(pending)
+		}()
+		diagnose(WARNING, Fields{"timeout": w.Timeout}, "write timed out")
+		return w.fallback(p)
+	}
+}
+
+func (w *TimeoutWriter) fallback(p []byte) (int, error) {
+	if w.Fallback != nil {
+		return w.Fallback.Write(p)
+	}
+	return len(p), nil
+}
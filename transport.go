@@ -0,0 +1,127 @@
+//go:build !tinygo
+// +build !tinygo
+
+package slogx
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRedactHeaders are the headers whose values a Transport redacts.
+var DefaultRedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+const redacted = "[REDACTED]"
+
+// Transport is an http.RoundTripper that logs each request with the fields
+// method, url, status, duration and the Fields of the request context,
+// such as a retry attempt added with ContextWithFields. Responses with a
+// Retry-After header add the field retry_after. Failed requests are logged
+// at ERROR Level and server errors at WARNING Level.
+type Transport struct {
+	Base          http.RoundTripper
+	Logger        *Logger
+	Level         Level
+	Headers       bool
+	RedactHeaders []string
+	MaxBody       int
+	RedactBody    func(body []byte) []byte
+}
+
+// NewTransport returns a new Transport logging requests sent by base at
+// INFO Level. If base is nil, http.DefaultTransport is used.
+func NewTransport(logger *Logger, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		Base:          base,
+		Logger:        logger,
+		Level:         INFO,
+		RedactHeaders: DefaultRedactHeaders,
+	}
+}
+
+// RoundTrip sends the request with the Base and logs it. If Headers is
+// set, the request and response headers are logged with the values of
+// RedactHeaders redacted. If MaxBody is set, up to MaxBody bytes of the
+// request and response bodies are logged, passed through RedactBody if set.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !t.Logger.enabled(t.Level) && !t.Logger.enabled(ERROR) {
+		return t.Base.RoundTrip(r)
+	}
+	fields := Fields{}
+	for k, v := range FieldsFromContext(r.Context()) {
+		fields[k] = v
+	}
+	fields["method"] = r.Method
+	fields["url"] = r.URL.Redacted()
+	if t.Headers {
+		fields["request_headers"] = t.headers(r.Header)
+	}
+	if t.MaxBody > 0 && r.GetBody != nil {
+		if body, err := r.GetBody(); err == nil {
+			prefix, _ := io.ReadAll(io.LimitReader(body, int64(t.MaxBody)))
+			body.Close()
+			fields["request_body"] = t.body(prefix)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(r)
+	fields["duration"] = time.Since(start)
+	if err != nil {
+		fields["error"] = err
+		t.Logger.log(ERROR, fields, r.Method+" "+r.URL.Redacted()+" failed")
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		fields["retry_after"] = v
+	}
+	if t.Headers {
+		fields["response_headers"] = t.headers(resp.Header)
+	}
+	if t.MaxBody > 0 && resp.Body != nil {
+		prefix, _ := io.ReadAll(io.LimitReader(resp.Body, int64(t.MaxBody)))
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+		fields["response_body"] = t.body(prefix)
+	}
+	level := t.Level
+	if resp.StatusCode >= 500 {
+		level = WARNING
+	}
+	t.Logger.log(level, fields, r.Method+" "+r.URL.Redacted()+" "+resp.Status)
+	return resp, nil
+}
+
+// headers returns the header values with the RedactHeaders redacted.
+func (t *Transport) headers(h http.Header) Fields {
+	fields := Fields{}
+	for k, v := range h {
+		fields[k] = strings.Join(v, ", ")
+	}
+	for _, k := range t.RedactHeaders {
+		k = http.CanonicalHeaderKey(k)
+		if _, ok := fields[k]; ok {
+			fields[k] = redacted
+		}
+	}
+	return fields
+}
+
+func (t *Transport) body(b []byte) string {
+	if t.RedactBody != nil {
+		b = t.RedactBody(b)
+	}
+	return string(b)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
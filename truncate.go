@@ -0,0 +1,56 @@
+package slogx
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const ellipsis = "…"
+
+// SetMaxMessageLength sets the maximum length of messages in bytes. Longer
+// messages are truncated with an ellipsis and the field truncated=true is
+// added. Zero disables the limit.
+func (l *Logger) SetMaxMessageLength(n int) {
+	l.update(func(c *config) {
+		c.maxMessage = n
+	})
+}
+
+// SetMaxFieldLength sets the maximum length of formatted field values in
+// bytes. Longer values are truncated like messages. Zero disables the limit.
+func (l *Logger) SetMaxFieldLength(n int) {
+	l.update(func(c *config) {
+		c.maxField = n
+	})
+}
+
+func (c *config) truncate(e *Entry) {
+	truncated := false
+	if c.maxMessage > 0 && len(e.Message) > c.maxMessage {
+		e.Message = truncateString(e.Message, c.maxMessage)
+		truncated = true
+	}
+	fields := make(Fields, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		if c.maxField > 0 {
+			if s := fmt.Sprint(v); len(s) > c.maxField {
+				v = truncateString(s, c.maxField)
+				truncated = true
+			}
+		}
+		fields[k] = v
+	}
+	if truncated {
+		fields["truncated"] = true
+		e.Fields = fields
+	}
+}
+
+// truncateString cuts s to at most n bytes on a rune boundary and appends
+// an ellipsis.
+func truncateString(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + ellipsis
+}
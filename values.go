@@ -0,0 +1,99 @@
+package slogx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// rawValuer is implemented by field values that are formatted for humans
+// by text encoders and encoded as raw numbers by structured encoders.
+type rawValuer interface {
+	raw() interface{}
+}
+
+// Duration is a field value formatted like 1.2s in text and encoded as
+// nanoseconds by structured encoders.
+type Duration time.Duration
+
+func (d Duration) String() string {
+	v := time.Duration(d)
+	abs := v
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= time.Minute:
+		v = v.Round(time.Second)
+	case abs >= time.Second:
+		v = v.Round(10 * time.Millisecond)
+	case abs >= time.Millisecond:
+		v = v.Round(10 * time.Microsecond)
+	case abs >= time.Microsecond:
+		v = v.Round(10 * time.Nanosecond)
+	}
+	return v.String()
+}
+
+// MarshalJSON returns the Duration in nanoseconds.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(d), 10), nil
+}
+
+func (d Duration) raw() interface{} {
+	return int64(d)
+}
+
+// Bytes is a field value formatted like 3.4 MiB in text and encoded as a
+// number of bytes by structured encoders.
+type Bytes int64
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+func (b Bytes) String() string {
+	v := float64(b)
+	i := 0
+	for math.Abs(v) >= 1024 && i < len(byteUnits)-1 {
+		v /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d B", int64(b))
+	}
+	return fmt.Sprintf("%.1f %s", v, byteUnits[i])
+}
+
+// MarshalJSON returns the number of bytes.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(b), 10), nil
+}
+
+func (b Bytes) raw() interface{} {
+	return int64(b)
+}
+
+// Rate is a field value of events per second formatted like 1.2k/s in text
+// and encoded as a number by structured encoders.
+type Rate float64
+
+var rateUnits = []string{"", "k", "M", "G", "T"}
+
+func (r Rate) String() string {
+	v := float64(r)
+	i := 0
+	for math.Abs(v) >= 1000 && i < len(rateUnits)-1 {
+		v /= 1000
+		i++
+	}
+	return fmt.Sprintf("%.3g%s/s", v, rateUnits[i])
+}
+
+// MarshalJSON returns the number of events per second.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return strconv.AppendFloat(nil, float64(r), 'g', -1, 64), nil
+}
+
+func (r Rate) raw() interface{} {
+	return float64(r)
+}
@@ -0,0 +1,89 @@
+package slogx
+
+import (
+	"flag"
+	"strconv"
+)
+
+// LevelFromVerbosity returns the logging Level for a verbosity count, where
+// 0 is INFO, each positive step increases and each negative step
+// decreases the verbosity.
+func LevelFromVerbosity(n int) Level {
+	level := int(INFO) + n
+	if level < int(NONE) {
+		return NONE
+	}
+	if level > int(DEBUG) {
+		return DEBUG
+	}
+	return Level(level)
+}
+
+// Verbosity is a verbosity count set by repeated verbose and quiet flags.
+type Verbosity int
+
+// Level returns the logging Level for the Verbosity.
+func (v Verbosity) Level() Level {
+	return LevelFromVerbosity(int(v))
+}
+
+// Verbose returns a flag value increasing the Verbosity each time it is set.
+// It also implements pflag.Value with the type "count".
+func (v *Verbosity) Verbose() flag.Value {
+	return &verbosityFlag{v: v, step: 1}
+}
+
+// Quiet returns a flag value decreasing the Verbosity each time it is set.
+// It also implements pflag.Value with the type "count".
+func (v *Verbosity) Quiet() flag.Value {
+	return &verbosityFlag{v: v, step: -1}
+}
+
+// AddVerbosityFlags registers the flags -v, -vv, -vvv, -q and -qq on the
+// FlagSet and returns the resulting Verbosity.
+func AddVerbosityFlags(fs *flag.FlagSet) *Verbosity {
+	v := new(Verbosity)
+	fs.Var(v.Verbose(), "v", "increase verbosity (repeatable)")
+	fs.Var(&verbosityFlag{v: v, step: 2}, "vv", "increase verbosity by two")
+	fs.Var(&verbosityFlag{v: v, step: 3}, "vvv", "increase verbosity by three")
+	fs.Var(v.Quiet(), "q", "decrease verbosity (repeatable)")
+	fs.Var(&verbosityFlag{v: v, step: -2}, "qq", "decrease verbosity by two")
+	return v
+}
+
+type verbosityFlag struct {
+	v    *Verbosity
+	step int
+}
+
+func (f *verbosityFlag) String() string {
+	if f.v == nil {
+		return "0"
+	}
+	return strconv.Itoa(int(*f.v))
+}
+
+// Set changes the Verbosity by one step for an empty, "true" or "+1" value,
+// or by as many steps as a number value.
+func (f *verbosityFlag) Set(s string) error {
+	switch s {
+	case "", "true", "+1":
+		*f.v += Verbosity(f.step)
+	case "false":
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*f.v += Verbosity(f.step * n)
+	}
+	return nil
+}
+
+func (f *verbosityFlag) IsBoolFlag() bool {
+	return true
+}
+
+func (f *verbosityFlag) Type() string {
+	return "count"
+}
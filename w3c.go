@@ -0,0 +1,110 @@
+package slogx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultW3CFields are the fields written by a W3CEncoder by default.
+var DefaultW3CFields = []string{"date", "time", "x-level", "x-name", "x-message"}
+
+// w3cFieldKeys maps W3C field identifiers to the keys of entry Fields.
+var w3cFieldKeys = map[string]string{
+	"c-ip":           "remote_addr",
+	"cs-method":      "method",
+	"cs-uri-stem":    "path",
+	"cs-uri-query":   "query",
+	"sc-status":      "status",
+	"sc-bytes":       "bytes",
+	"time-taken":     "duration",
+	"cs(User-Agent)": "user_agent",
+	"cs(Referer)":    "referer",
+	"cs-username":    "user",
+	"x-request-id":   "request_id",
+}
+
+// W3CEncoder encodes entries in the W3C Extended Log File Format. The
+// #Version, #Date and #Fields directives are written before the first
+// entry. Identifiers other than date, time and the x-level, x-name,
+// x-file, x-line and x-message extensions are taken from the entry Fields,
+// either by their common field name (cs-method as method, sc-status as
+// status, ...) or by the identifier itself.
+type W3CEncoder struct {
+	Fields []string
+	Mutex  sync.Mutex
+	header bool
+}
+
+// NewW3CEncoder returns a new W3CEncoder writing the fields, or
+// DefaultW3CFields if none are given.
+func NewW3CEncoder(fields ...string) *W3CEncoder {
+	if len(fields) == 0 {
+		fields = DefaultW3CFields
+	}
+	return &W3CEncoder{
+		Fields: fields,
+	}
+}
+
+func newW3CEncoder(options map[string]string) (Encoder, error) {
+	return NewW3CEncoder(strings.Fields(options["fields"])...), nil
+}
+
+// Encode returns the Entry as a W3C log line, preceded by the directives
+// for the first Entry.
+func (enc *W3CEncoder) Encode(e Entry) ([]byte, error) {
+	t := e.Time.UTC()
+	values := make([]string, len(enc.Fields))
+	for i, field := range enc.Fields {
+		values[i] = w3cValue(field, t, e)
+	}
+	line := strings.Join(values, " ")
+
+	enc.Mutex.Lock()
+	defer enc.Mutex.Unlock()
+	if !enc.header {
+		enc.header = true
+		line = fmt.Sprintf("#Version: 1.0\n#Date: %s\n#Fields: %s\n%s",
+			t.Format("2006-01-02 15:04:05"), strings.Join(enc.Fields, " "), line)
+	}
+	return []byte(line), nil
+}
+
+func w3cValue(field string, t time.Time, e Entry) string {
+	var value interface{}
+	switch field {
+	case "date":
+		return t.Format("2006-01-02")
+	case "time":
+		return t.Format("15:04:05")
+	case "x-level":
+		return e.Level.String()
+	case "x-name":
+		value = e.Name
+	case "x-file":
+		value = e.File
+	case "x-line":
+		return fmt.Sprint(e.Line)
+	case "x-message":
+		value = e.Message
+	default:
+		var ok bool
+		value, ok = e.Fields[field]
+		if key := w3cFieldKeys[field]; !ok && key != "" {
+			value, ok = e.Fields[key]
+		}
+		if !ok {
+			return "-"
+		}
+	}
+	if d, ok := value.(time.Duration); ok {
+		return fmt.Sprintf("%.3f", d.Seconds())
+	}
+	s := fmt.Sprint(value)
+	if s == "" {
+		return "-"
+	}
+	return strings.NewReplacer(" ", "+", "\n", "+", "\t", "+").Replace(s)
+}
@@ -0,0 +1,20 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd
+
+package slogx
+
+import (
+	"os"
+	"strconv"
+)
+
+// terminalWidth returns the number of columns in the COLUMNS environment
+// variable if f is a character device, or 0 otherwise.
+func terminalWidth(f *os.File) int {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return 0
+	}
+	width, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return width
+}